@@ -0,0 +1,213 @@
+/**
+  Copyright (c) [2024] [JiangNan]
+  [pandas] is licensed under Mulan PSL v2.
+  You can use this software according to the terms and conditions of the Mulan PSL v2.
+  You may obtain a copy of Mulan PSL v2 at:
+           http://license.coscl.org.cn/MulanPSL2
+  THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND, EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT, MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+  See the Mulan PSL v2 for more details.
+*/
+
+package dataframe
+
+import (
+	"bufio"
+	"bytes"
+	"gitee.com/jn-qq/pandas/internal/arrow"
+	"gitee.com/jn-qq/pandas/series"
+	"io"
+	"os"
+)
+
+// 写入时每个记录批的默认行数
+const defaultBatchSize = 1 << 20
+
+// ArrowOptions Arrow IPC 读写配置
+//
+//	读取时整数及 Duration 为 series.Int，浮点数及有效位数不超过 15 位的 Decimal 为 series.Float，Bool 为 series.Bool，
+//	字符串及二进制为 series.String，超过 15 位的 Decimal 为保留精确值的 series.String（如 "12345678901234567.89"），
+//	Date 为 "2006-01-02" 格式、Time 为 "15:04:05" 格式、Timestamp 为 RFC 3339 格式的 series.String，字典编码列按字典值读取，
+//	基本类型的 List、LargeList、FixedSizeList 为 series.List(T)，结构体展开为以 "." 连接的列名，如 user.id；
+//	Map、Union、Interval 等不支持的列需通过 Columns 排除。有效位图标记的空值读取为 NaN（series.Bool 没有空值，读取为 false），字符串按原值读取，"NaN" 等不视为空值；写入时空值标记为空值
+type ArrowOptions struct {
+	Columns     []string // 读取的列及顺序，为 nil 时读取全部列
+	Stream      bool     // 写入流格式，默认写入文件格式（Feather V2）
+	Compression string   // 写入的压缩格式：lz4（LZ4 帧格式）、zstd，需通过 RegisterCodec 以对应名称注册，默认不压缩
+	BatchSize   int      // 写入时每个记录批的行数，默认 1048576
+}
+
+// ReadArrow 从Arrow IPC文件中读取表格，按文件头识别文件格式或流格式，压缩的记录批需通过 RegisterCodec 注册 lz4 或 zstd。
+// 数据损坏时返回的错误可通过 errors.Is(err, series.ErrCorruptData) 判断，不支持的列或压缩格式可通过 series.ErrUnsupportedOperation 判断
+func ReadArrow(filePath string, opts ArrowOptions) (df *DataFrame, err error) {
+	f, err := os.Open(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if closeErr := f.Close(); closeErr != nil && err == nil {
+			df, err = nil, closeErr
+		}
+	}()
+	info, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+	head := make([]byte, 6)
+	if _, err = io.ReadFull(f, head); err == nil && string(head) == "ARROW1" {
+		ar, err := arrow.NewFileReader(f, info.Size())
+		if err != nil {
+			return nil, err
+		}
+		return readArrow(ar, opts)
+	}
+	if _, err = f.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+	ar, err := arrow.NewStreamReader(bufio.NewReader(f))
+	if err != nil {
+		return nil, err
+	}
+	return readArrow(ar, opts)
+}
+
+// ReadArrowFrom 从 r 中读取Arrow IPC表格，流格式逐条读取，文件格式读取全部数据后解析
+func ReadArrowFrom(r io.Reader, opts ArrowOptions) (*DataFrame, error) {
+	br := bufio.NewReader(r)
+	if head, _ := br.Peek(6); string(head) == "ARROW1" {
+		data, err := io.ReadAll(br)
+		if err != nil {
+			return nil, err
+		}
+		ar, err := arrow.NewFileReader(bytes.NewReader(data), int64(len(data)))
+		if err != nil {
+			return nil, err
+		}
+		return readArrow(ar, opts)
+	}
+	ar, err := arrow.NewStreamReader(br)
+	if err != nil {
+		return nil, err
+	}
+	return readArrow(ar, opts)
+}
+
+// 读取全部记录批为一个表格
+func readArrow(ar *arrow.Reader, opts ArrowOptions) (*DataFrame, error) {
+	ar.Decompress = decompressBytes
+	columns, err := selectColumns(ar.Columns(), func(c *arrow.Column) string { return c.Name }, opts.Columns)
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(columns))
+	types := make([]series.Type, 0, len(columns))
+	for _, c := range columns {
+		if c.Err != nil {
+			return nil, c.Err
+		}
+		t := arrowType(c.Kind)
+		if c.List {
+			t = series.List(t)
+		}
+		names = append(names, c.Name)
+		types = append(types, t)
+	}
+	values := make([][]any, len(columns))
+	for {
+		batch, err := ar.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		for j, c := range columns {
+			column, err := batch.Column(c)
+			if err != nil {
+				return nil, err
+			}
+			values[j] = append(values[j], column...)
+		}
+	}
+	return loadColumns(names, types, values)
+}
+
+func arrowType(kind arrow.Kind) series.Type {
+	switch kind {
+	case arrow.KindInt:
+		return series.Int
+	case arrow.KindFloat:
+		return series.Float
+	case arrow.KindBool:
+		return series.Bool
+	default:
+		return series.String
+	}
+}
+
+// WriteArrow 将表格写入Arrow IPC文件，各列均写为可空列：series.Int 为 Int64，series.Float 为 Float64，
+// series.String 为 Utf8，series.Bool 为 Bool，series.Datetime 为 Records 格式的 Utf8，series.List(T) 为 List
+func (df *DataFrame) WriteArrow(p string, opts ArrowOptions) (err error) {
+	newFile, err := os.Create(p)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if closeErr := newFile.Close(); closeErr != nil && err == nil {
+			err = closeErr
+		}
+	}()
+	return df.WriteArrowTo(newFile, opts)
+}
+
+// WriteArrowTo 将表格以Arrow IPC格式写入 w，每 opts.BatchSize 行为一个记录批
+func (df *DataFrame) WriteArrowTo(w io.Writer, opts ArrowOptions) error {
+	switch opts.Compression {
+	case "", "lz4", "zstd":
+	default:
+		return series.NewError(series.ErrInvalidArgument, MsgUnknownCodec, opts.Compression)
+	}
+	size := opts.BatchSize
+	if size <= 0 {
+		size = defaultBatchSize
+	}
+	fields := make([]arrow.Field, 0, df.cols)
+	columns := make([][]any, 0, df.cols)
+	for i := range df.columns {
+		s := &df.columns[i]
+		t := series.Type(s.Type())
+		field := arrow.Field{Name: s.Name, List: t.IsList()}
+		switch t.Elem() {
+		case series.Int:
+			field.Kind = arrow.KindInt
+		case series.Float:
+			field.Kind = arrow.KindFloat
+		case series.Bool:
+			field.Kind = arrow.KindBool
+		case series.String, series.Datetime:
+			field.Kind = arrow.KindString
+		default:
+			return &series.UnsupportedOperationError{Op: "WriteArrow", Type: t}
+		}
+		fields = append(fields, field)
+		columns = append(columns, nullableValues(s))
+	}
+	newWriter := arrow.NewFileWriter
+	if opts.Stream {
+		newWriter = arrow.NewStreamWriter
+	}
+	aw, err := newWriter(w, fields, opts.Compression, compressBytes)
+	if err != nil {
+		return err
+	}
+	for start := 0; start < df.rows; start += size {
+		end := min(start+size, df.rows)
+		batch := make([][]any, 0, len(columns))
+		for _, column := range columns {
+			batch = append(batch, column[start:end])
+		}
+		if err = aw.WriteBatch(batch); err != nil {
+			return err
+		}
+	}
+	return aw.Close()
+}
@@ -0,0 +1,146 @@
+/**
+  Copyright (c) [2024] [JiangNan]
+  [pandas] is licensed under Mulan PSL v2.
+  You can use this software according to the terms and conditions of the Mulan PSL v2.
+  You may obtain a copy of Mulan PSL v2 at:
+           http://license.coscl.org.cn/MulanPSL2
+  THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND, EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT, MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+  See the Mulan PSL v2 for more details.
+*/
+
+package dataframe
+
+import (
+	"gitee.com/jn-qq/pandas/series"
+	"io"
+	"io/fs"
+	"os"
+)
+
+// CSVChunks CSV 分块读取器，每次读取 chunkSize 行生成一个 DataFrame，各块列名及类型相同
+//
+//	for chunks.Next() {
+//		df := chunks.DataFrame()
+//	}
+//	if err := chunks.Err(); err != nil {
+//	}
+type CSVChunks struct {
+	file      io.Closer // ReadCSVChunks 打开的文件，由 Close 关闭
+	reader    *csvReader
+	chunkSize int
+	types     []series.Type // 第一块确定的列类型，后续各块沿用
+	report    *progress
+	df        *DataFrame
+	err       error
+	done      bool
+}
+
+// ReadCSVChunks 分块读取CSV，未指定列类型时由第一块数据推断，后续块中无法转换的值按 Sheets.Errors 及 Strict 处理，
+// 使用完毕后需调用 Close
+//
+//	chunkSize: 每块行数，小于等于 0 时为 series.DefaultChunkSize
+func ReadCSVChunks(filePath string, sheet Sheets, chunkSize int) (*CSVChunks, error) {
+	f, err := os.Open(filePath)
+	if err != nil {
+		return nil, err
+	}
+	if sheet.SheetName == "" {
+		sheet.SheetName = filePath
+	}
+	chunks := newCSVChunks(f, sheet, chunkSize, filePath)
+	chunks.file = f
+	return chunks, nil
+}
+
+// ReadCSVChunksFS 从文件系统 fsys 中分块读取CSV，使用完毕后需调用 Close
+func ReadCSVChunksFS(fsys fs.FS, name string, sheet Sheets, chunkSize int) (*CSVChunks, error) {
+	f, err := fsys.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	if sheet.SheetName == "" {
+		sheet.SheetName = name
+	}
+	chunks := newCSVChunks(f, sheet, chunkSize, name)
+	chunks.file = f
+	return chunks, nil
+}
+
+// ReadCSVChunksFrom 从 r 中分块读取CSV，r 由调用方关闭，使用完毕后仍需调用 Close
+func ReadCSVChunksFrom(r io.Reader, sheet Sheets, chunkSize int) *CSVChunks {
+	return newCSVChunks(r, sheet, chunkSize, "")
+}
+
+// 创建分块读取器，按文件名 name 的扩展名或文件头识别压缩格式
+func newCSVChunks(r io.Reader, sheet Sheets, chunkSize int, name string) *CSVChunks {
+	if chunkSize <= 0 {
+		chunkSize = series.DefaultChunkSize
+	}
+	return &CSVChunks{
+		reader:    newCSVReader(r, sheet, name),
+		chunkSize: chunkSize,
+		types:     sheet.ColsType,
+		report:    newProgress(sheet.Progress),
+	}
+}
+
+// Next 读取下一块，没有更多数据或出错时返回 false
+func (c *CSVChunks) Next() bool {
+	c.df = nil
+	if c.err != nil || c.done {
+		return false
+	}
+	var rows [][]string
+	var rowNums []int
+	for len(rows) < c.chunkSize {
+		record, row, err := c.reader.next()
+		if err == io.EOF {
+			c.done = true
+			c.report.done()
+			break
+		}
+		if err != nil {
+			c.err = err
+			return false
+		}
+		rows = append(rows, record)
+		rowNums = append(rowNums, row)
+		c.report.add(1)
+	}
+	if len(rows) == 0 {
+		return false
+	}
+	df, err := loadRecord(rows, c.reader.header, c.types, c.reader.sheet, rowNums)
+	if err != nil {
+		c.err = err
+		return false
+	}
+	if c.types == nil {
+		for _, column := range df.columns {
+			c.types = append(c.types, series.Type(column.Type()))
+		}
+	}
+	c.df = df
+	return true
+}
+
+// DataFrame 返回当前块
+func (c *CSVChunks) DataFrame() *DataFrame {
+	return c.df
+}
+
+// Err 返回读取过程中的错误
+func (c *CSVChunks) Err() error {
+	return c.err
+}
+
+// Close 关闭解压读取器及 ReadCSVChunks、ReadCSVChunksFS 打开的文件
+func (c *CSVChunks) Close() error {
+	err := c.reader.close()
+	if c.file != nil {
+		if closeErr := c.file.Close(); closeErr != nil && err == nil {
+			err = closeErr
+		}
+	}
+	return err
+}
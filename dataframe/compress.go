@@ -0,0 +1,218 @@
+/**
+  Copyright (c) [2024] [JiangNan]
+  [pandas] is licensed under Mulan PSL v2.
+  You can use this software according to the terms and conditions of the Mulan PSL v2.
+  You may obtain a copy of Mulan PSL v2 at:
+           http://license.coscl.org.cn/MulanPSL2
+  THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND, EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT, MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+  See the Mulan PSL v2 for more details.
+*/
+
+package dataframe
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"gitee.com/jn-qq/pandas/series"
+	"io"
+	"path"
+	"strings"
+	"sync"
+)
+
+// Codec 压缩格式，注册后读写文件时按扩展名或文件头自动解压、压缩
+type Codec struct {
+	Name       string                                    // 名称，如 "gzip"
+	Extensions []string                                  // 文件扩展名，如 ".gz"，写入时按扩展名选择压缩格式
+	Magic      [][]byte                                  // 文件头，读取时用于识别，可为空
+	Match      func(head []byte) bool                    // Magic 匹配后进一步校验文件头，head 至少含 8 字节（文件更短时除外），为 nil 时不校验
+	NewReader  func(r io.Reader) (io.ReadCloser, error)  // 创建解压读取器，关闭时不应关闭 r
+	NewWriter  func(w io.Writer) (io.WriteCloser, error) // 创建压缩写入器，关闭时不应关闭 w，为 nil 时不支持写入
+}
+
+var codecs = struct {
+	sync.RWMutex
+	list []Codec
+}{list: []Codec{
+	{
+		Name:       "gzip",
+		Extensions: []string{".gz", ".gzip"},
+		Magic:      [][]byte{{0x1f, 0x8b}},
+		NewReader:  func(r io.Reader) (io.ReadCloser, error) { return gzip.NewReader(r) },
+		NewWriter:  func(w io.Writer) (io.WriteCloser, error) { return gzip.NewWriter(w), nil },
+	},
+	{
+		Name:       "zlib",
+		Extensions: []string{".zz", ".zlib"},
+		Magic:      [][]byte{{0x78}},
+		Match:      zlibHeader,
+		NewReader:  zlib.NewReader,
+		NewWriter:  func(w io.Writer) (io.WriteCloser, error) { return zlib.NewWriter(w), nil },
+	},
+}}
+
+// RegisterCodec 注册压缩格式，名称相同时替换已注册的格式，如注册 zstd：
+//
+//	dataframe.RegisterCodec(dataframe.Codec{
+//		Name:       "zstd",
+//		Extensions: []string{".zst"},
+//		Magic:      [][]byte{{0x28, 0xb5, 0x2f, 0xfd}},
+//		NewReader: func(r io.Reader) (io.ReadCloser, error) {
+//			d, err := zstd.NewReader(r)
+//			if err != nil {
+//				return nil, err
+//			}
+//			return d.IOReadCloser(), nil
+//		},
+//		NewWriter: func(w io.Writer) (io.WriteCloser, error) { return zstd.NewWriter(w) },
+//	})
+func RegisterCodec(c Codec) {
+	codecs.Lock()
+	defer codecs.Unlock()
+	for i := range codecs.list {
+		if codecs.list[i].Name == c.Name {
+			codecs.list[i] = c
+			return
+		}
+	}
+	codecs.list = append(codecs.list, c)
+}
+
+// 按文件名的扩展名查找压缩格式
+func codecByFile(name string) (Codec, bool) {
+	ext := strings.ToLower(path.Ext(name))
+	if ext == "" {
+		return Codec{}, false
+	}
+	codecs.RLock()
+	defer codecs.RUnlock()
+	for _, c := range codecs.list {
+		for _, e := range c.Extensions {
+			if strings.ToLower(e) == ext {
+				return c, true
+			}
+		}
+	}
+	return Codec{}, false
+}
+
+// 按名称查找压缩格式
+func codecByName(name string) (Codec, bool) {
+	codecs.RLock()
+	defer codecs.RUnlock()
+	for _, c := range codecs.list {
+		if c.Name == name {
+			return c, true
+		}
+	}
+	return Codec{}, false
+}
+
+// 按文件头查找压缩格式
+func codecByMagic(head []byte) (Codec, bool) {
+	codecs.RLock()
+	defer codecs.RUnlock()
+	for _, c := range codecs.list {
+		for _, magic := range c.Magic {
+			if len(magic) > 0 && bytes.HasPrefix(head, magic) && (c.Match == nil || c.Match(head)) {
+				return c, true
+			}
+		}
+	}
+	return Codec{}, false
+}
+
+// 文件头最大长度，有 Magic 时至少为 8 字节供 Match 校验
+func magicSize() int {
+	codecs.RLock()
+	defer codecs.RUnlock()
+	size := 0
+	for _, c := range codecs.list {
+		for _, magic := range c.Magic {
+			size = max(size, len(magic), 8)
+		}
+	}
+	return size
+}
+
+// 校验 zlib 文件头（RFC 1950）：压缩方法为 deflate，窗口不超过 32K，未使用预设字典，且 CMF、FLG 组成的 16 位数为 31 的倍数
+func zlibHeader(head []byte) bool {
+	if len(head) < 2 {
+		return false
+	}
+	cmf, flg := head[0], head[1]
+	return cmf&0x0f == 8 && cmf>>4 <= 7 && flg&0x20 == 0 && (uint16(cmf)<<8|uint16(flg))%31 == 0
+}
+
+// 按文件名 name 的扩展名或 r 的文件头识别压缩格式并返回解压读取器，未压缩时原样读取。
+// 关闭返回值时不关闭 r
+func decompress(r io.Reader, name string) (io.ReadCloser, error) {
+	c, ok := codecByFile(name)
+	if !ok {
+		size := magicSize()
+		if size == 0 {
+			return io.NopCloser(r), nil
+		}
+		br := bufio.NewReader(r)
+		head, _ := br.Peek(size)
+		r = br
+		if c, ok = codecByMagic(head); !ok {
+			return io.NopCloser(r), nil
+		}
+	}
+	return c.NewReader(r)
+}
+
+// 按文件名 name 的扩展名选择压缩格式并返回压缩写入器，无对应格式时原样写入。
+// 关闭返回值时不关闭 w
+func compress(w io.Writer, name string) (io.WriteCloser, error) {
+	c, ok := codecByFile(name)
+	if !ok {
+		return nopWriteCloser{w}, nil
+	}
+	if c.NewWriter == nil {
+		return nil, series.NewError(series.ErrUnsupportedOperation, MsgCodecNoWriter, c.Name)
+	}
+	return c.NewWriter(w)
+}
+
+// 通过已注册的同名压缩格式解压数据块，size 为解压后的大小
+func decompressBytes(name string, data []byte, size int) ([]byte, error) {
+	c, ok := codecByName(name)
+	if !ok {
+		return nil, series.NewError(series.ErrUnsupportedOperation, MsgUnknownCodec, name)
+	}
+	r, err := c.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	out := bytes.NewBuffer(make([]byte, 0, size))
+	if _, err = io.Copy(out, r); err != nil {
+		_ = r.Close()
+		return nil, err
+	}
+	return out.Bytes(), r.Close()
+}
+
+// 通过已注册的同名压缩格式压缩数据块
+func compressBytes(name string, data []byte) ([]byte, error) {
+	c, ok := codecByName(name)
+	if !ok || c.NewWriter == nil {
+		return nil, series.NewError(series.ErrUnsupportedOperation, MsgCodecNoWriter, name)
+	}
+	var buf bytes.Buffer
+	w, err := c.NewWriter(&buf)
+	if err != nil {
+		return nil, err
+	}
+	if _, err = w.Write(data); err != nil {
+		_ = w.Close()
+		return nil, err
+	}
+	if err = w.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
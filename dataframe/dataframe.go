@@ -11,14 +11,16 @@
 package dataframe
 
 import (
+	"context"
 	"fmt"
 	"gitee.com/jn-qq/go-tools/data"
-	"gitee.com/jn-qq/go-tools/pandas/series"
+	"gitee.com/jn-qq/pandas/series"
 	"github.com/apcera/termtables"
 	"reflect"
 	"slices"
 	"strconv"
 	"strings"
+	"time"
 )
 
 type DataFrame struct {
@@ -29,14 +31,15 @@ type DataFrame struct {
 
 // New 创建 DataFrame 数据对象
 //
-//	columns: 待输入数据，可以为 []any 。series.Series / []int / []float64 / []string / []bool
+//	columns: 待输入数据，可以为 []any 。series.Series / []int / []float64 / []string / []bool，
+//	列表列可以为 [][]int / [][]float64 / [][]string / [][]bool
 //	colsName: 列名，当 columns 为 series.Series 可为nil
 func New(columns []any, colsName []string) (*DataFrame, error) {
 	df := &DataFrame{columns: make([]series.Series, 0), cols: 0, rows: 0}
 	if columns == nil {
 		return df, nil
-	} else if !equalLength(columns, 0) {
-		return df, fmt.Errorf("columns must equal length")
+	} else if err := checkLength(columns, 0); err != nil {
+		return df, err
 	}
 
 	for i, column := range columns {
@@ -45,6 +48,8 @@ func New(columns []any, colsName []string) (*DataFrame, error) {
 		switch value := column.(type) {
 		case *series.Series:
 			ns = value.Copy()
+		case series.Series:
+			ns = value.Copy()
 		case []bool:
 			ns, err = series.NewSeries(value, series.Bool, colsName[i])
 		case []float64:
@@ -53,6 +58,18 @@ func New(columns []any, colsName []string) (*DataFrame, error) {
 			ns, err = series.NewSeries(value, series.String, colsName[i])
 		case []int:
 			ns, err = series.NewSeries(value, series.Int, colsName[i])
+		case []time.Time:
+			ns, err = series.NewSeries(value, series.Datetime, colsName[i])
+		case [][]bool:
+			ns, err = series.NewSeries(value, series.List(series.Bool), colsName[i])
+		case [][]float64:
+			ns, err = series.NewSeries(value, series.List(series.Float), colsName[i])
+		case [][]string:
+			ns, err = series.NewSeries(value, series.List(series.String), colsName[i])
+		case [][]int:
+			ns, err = series.NewSeries(value, series.List(series.Int), colsName[i])
+		default:
+			err = &series.TypeMismatchError{Want: "series.Series", Got: fmt.Sprintf("%T", value)}
 		}
 		if err != nil {
 			return nil, err
@@ -60,7 +77,7 @@ func New(columns []any, colsName []string) (*DataFrame, error) {
 		df.columns = append(df.columns, *ns)
 	}
 
-	df.Size()
+	df.resize()
 
 	return df, nil
 }
@@ -69,24 +86,38 @@ func New(columns []any, colsName []string) (*DataFrame, error) {
 //
 //	rows: 待输入数据
 //	colsName：每列名称，当为 nil 时，rows[0]作为每列名称
-//	colsType：每列数据类型
+//	colsType：每列数据类型，当为 nil 时，根据数据自动推断
 func LoadRecord(rows [][]string, colsName []string, colsType []series.Type) (*DataFrame, error) {
+	return loadRecord(rows, colsName, colsType, Sheets{}, nil)
+}
+
+// 按读取配置用二维字符串切片创建 DataFrame 数据对象
+//
+//	sheet：读取配置，使用其中的数值解析器、空值标记，收集转换失败的单元格，严格模式下存在失败时返回错误
+//	rowNums：每行数据在文件中的行号，为 nil 时为在 rows 中的行号
+func loadRecord(rows [][]string, colsName []string, colsType []series.Type, sheet Sheets, rowNums []int) (*DataFrame, error) {
 	// 1.检查输入数据
-	if rows == nil {
-		return nil, fmt.Errorf("输入数据不能为空")
-	} else if !equalLength(rows, 0) {
-		return nil, fmt.Errorf("各子切片数据个数不同")
-	} else if len(rows[0]) != len(colsType) {
-		return nil, fmt.Errorf("数据列数与类型列数不相等")
+	if len(rows) == 0 {
+		return nil, ErrEmptyData
+	} else if err := checkLength(rows, 0); err != nil {
+		return nil, err
+	} else if colsType != nil && len(rows[0]) != len(colsType) {
+		return nil, &series.LengthMismatchError{Want: len(rows[0]), Got: len(colsType)}
 	}
 	// 判断 数据列数是否与类型、名称对应
-	maxCol := len(colsType)
+	maxCol := len(rows[0])
 	if colsName != nil && maxCol != len(colsName) {
-		return nil, fmt.Errorf("数据列数与名称列数不相等")
+		return nil, &series.LengthMismatchError{Want: maxCol, Got: len(colsName)}
 	}
 	if colsName == nil {
 		colsName = rows[0]
 		rows = rows[1:]
+		if rowNums == nil {
+			rowNums = data.Range(2, len(rows)+2, 1)
+		}
+	}
+	if rowNums == nil {
+		rowNums = data.Range(1, len(rows)+1, 1)
 	}
 	maxRow := len(rows)
 
@@ -107,9 +138,44 @@ func LoadRecord(rows [][]string, colsName []string, colsType []series.Type) (*Da
 			}
 		}
 	}
+	// 推断或覆盖列类型
+	colsType = slices.Clone(colsType)
+	if colsType == nil {
+		colsType = make([]series.Type, maxCol)
+		inferRows := sheet.InferRows
+		if inferRows <= 0 {
+			inferRows = 1000
+		}
+		for i, value := range values {
+			colsType[i] = series.InferType(value[:min(len(value), inferRows)], sheet.loadOptions(colsName[i]))
+		}
+	}
+	for i, name := range colsName {
+		if t, ok := sheet.Types[name]; ok {
+			colsType[i] = t
+		}
+	}
 	// 生成 series.Series 对象
+	var cellErrors CellErrors
 	for i, value := range values {
-		df.columns = append(df.columns, *series.LoadRecords(value, colsType[i], colsName[i]))
+		ns, errs := series.LoadRecordsWith(value, colsType[i], colsName[i], sheet.loadOptions(colsName[i]))
+		df.columns = append(df.columns, *ns)
+		for _, e := range errs {
+			cellErrors = append(cellErrors, &CellError{
+				Sheet:  sheet.SheetName,
+				Row:    rowNums[e.Index],
+				Col:    max(sheet.SCol, 1) + i,
+				Column: colsName[i],
+				Value:  e.Value,
+				Type:   e.Type,
+			})
+		}
+	}
+	if sheet.Errors != nil {
+		*sheet.Errors = append(*sheet.Errors, cellErrors...)
+	}
+	if sheet.Strict && cellErrors != nil {
+		return nil, cellErrors
 	}
 	return df, nil
 }
@@ -119,9 +185,9 @@ func LoadRecord(rows [][]string, colsName []string, colsType []series.Type) (*Da
 //	values map[string]T, T = []int、[]string、[]float64、[]bool
 func LoadMap(values map[string]any) (*DataFrame, error) {
 	if values == nil {
-		return nil, fmt.Errorf("请输入数据")
-	} else if !equalLength(values, 0) {
-		return nil, fmt.Errorf("各列数据不相等")
+		return nil, ErrEmptyData
+	} else if err := checkLength(values, 0); err != nil {
+		return nil, err
 	}
 	var colsName []string
 	var columns []any
@@ -147,10 +213,14 @@ func (df *DataFrame) Records(isRow bool, hasColName bool) [][]string {
 		if hasColName {
 			res = append(res, df.Names())
 		}
+		columns := make([][]string, 0, df.cols)
+		for _, column := range df.columns {
+			columns = append(columns, column.Records())
+		}
 		for i := 0; i < df.rows; i++ {
 			var rows []string
-			for _, column := range df.columns {
-				rows = append(rows, column.Element(i).Records())
+			for _, column := range columns {
+				rows = append(rows, column[i])
 			}
 			res = append(res, rows)
 		}
@@ -192,18 +262,23 @@ func (df *DataFrame) String() string {
 }
 
 func (df *DataFrame) Print(isComplete bool) (str string) {
+	return df.PrintIn(isComplete, series.Language())
+}
+
+// PrintIn 按指定语言输出表格
+func (df *DataFrame) PrintIn(isComplete bool, lang series.Lang) string {
 
 	// 创建表格对象
 	table := termtables.CreateTable()
 	// 添加标题
 	if df.cols == 0 || df.rows == 0 {
-		table.AddTitle("DataFrame Is Empty")
+		table.AddTitle(series.Message(lang, MsgPrintEmpty))
 	} else {
-		table.AddTitle(fmt.Sprintf("DataFrame Size：%d x %d", df.cols, df.rows))
+		table.AddTitle(series.Message(lang, MsgPrintTitle, df.cols, df.rows))
 	}
 	// 添加表头
-	colsName := slices.Insert(df.Names(), 0, "Index")
-	colsType := slices.Insert(df.Types(), 0, "Types")
+	colsName := slices.Insert(df.Names(), 0, series.Message(lang, MsgPrintIndex))
+	colsType := slices.Insert(df.Types(), 0, series.Message(lang, MsgPrintTypes))
 	var headers, dTypes []any
 	for i := 0; i < df.cols+1; i++ {
 		headers = append(headers, colsName[i])
@@ -233,15 +308,26 @@ func (df *DataFrame) Print(isComplete bool) (str string) {
 	return table.Render()
 }
 
-// Size 更新并返回二维数组大小
+// Size 返回列数及行数
 func (df *DataFrame) Size() (cols, rows int) {
+	return df.cols, df.rows
+}
+
+// 按列数据更新行列数，修改列后调用
+func (df *DataFrame) resize() {
 	df.cols = len(df.columns)
 	if df.cols > 0 {
 		df.rows = df.columns[0].Len()
 	} else {
 		df.rows = 0
 	}
-	return df.cols, df.rows
+}
+
+// 将各列标记为与其他表共享，之后 Copy、SubSet 等操作不再写入原表
+func (df *DataFrame) share() {
+	for i := range df.columns {
+		_, _ = df.columns[i].SubSet()
+	}
 }
 
 func (df *DataFrame) NCols() int {
@@ -252,12 +338,12 @@ func (df *DataFrame) NRows() int {
 	return df.rows
 }
 
-// Columns 返回列
+// Columns 返回列的副本，与表格共享元素直至其中一方写入，修改返回的列不影响表格
 func (df *DataFrame) Columns(name string) (series.Series, error) {
 	if indexCol := slices.IndexFunc(df.columns, func(s series.Series) bool { return s.Name == name }); indexCol == -1 {
-		return series.Series{}, fmt.Errorf("name: %s is not found", name)
+		return series.Series{}, &ColumnNotFoundError{Name: name}
 	} else {
-		return df.columns[indexCol], nil
+		return *df.columns[indexCol].Copy(), nil
 	}
 }
 
@@ -278,30 +364,42 @@ func (df *DataFrame) SelectCols(names ...string) *DataFrame {
 	}
 }
 
-// Rows 返回行
+// Rows 返回行，行号越界时返回 nil
 func (df *DataFrame) Rows(r int) map[string]series.Element {
-	if r >= df.rows {
+	if r < 0 || r >= df.rows {
 		return nil
 	}
 	var rows = make(map[string]series.Element)
-	for _, column := range df.columns {
-		rows[column.Name] = column.Element(r)
+	for i, column := range df.columns {
+		rows[column.Name] = df.columns[i].Element(r)
 	}
 	return rows
 }
 
-// Cell 返回指定单元格元素
+// Cell 返回指定单元格元素，列不存在或行号越界时返回 nil
 func (df *DataFrame) Cell(r int, name string) series.Element {
-	s, _ := df.Columns(name)
-	return s.Element(r)
+	elem, _ := df.At(r, name)
+	return elem
 }
 
-// Copy 复制
+// At 返回指定单元格元素，列不存在时返回 *ColumnNotFoundError，行号越界时返回 series.ErrIndexOutOfRange
+func (df *DataFrame) At(r int, name string) (series.Element, error) {
+	i := slices.IndexFunc(df.columns, func(s series.Series) bool { return s.Name == name })
+	if i == -1 {
+		return nil, &ColumnNotFoundError{Name: name}
+	}
+	return df.columns[i].At(r)
+}
+
+// Copy 复制，各列与原表共享元素直至其中一方写入，两者的修改互不影响
 func (df *DataFrame) Copy() *DataFrame {
 	frame := DataFrame{
-		columns: slices.Clone(df.columns),
+		columns: make([]series.Series, 0, len(df.columns)),
+	}
+	for i := range df.columns {
+		frame.columns = append(frame.columns, *df.columns[i].Copy())
 	}
-	frame.Size()
+	frame.resize()
 	return &frame
 }
 
@@ -313,42 +411,42 @@ func (df *DataFrame) Set(index int, values any) error {
 	switch value := values.(type) {
 	case []any:
 		if len(value) != df.cols {
-			return fmt.Errorf("length of columns must equal %d", df.cols)
+			return &series.LengthMismatchError{Want: df.cols, Got: len(value)}
 		}
 		for i, v := range value {
 			if index >= df.rows {
 				if err := df.columns[i].Append(v); err != nil {
 					return err
 				}
-			} else {
-				df.columns[i].Element(index).Set(v)
+			} else if err := df.columns[i].SetAt(index, v); err != nil {
+				return err
 			}
 		}
 	case map[string]any:
 		for k, v := range value {
 			i := slices.IndexFunc(df.columns, func(s series.Series) bool { return s.Name == k })
 			if i == -1 {
-				return fmt.Errorf("column %s not found", k)
+				return &ColumnNotFoundError{Name: k}
 			}
 			if index >= df.rows {
 				if err := df.columns[i].Append(v); err != nil {
 					return err
 				}
-			} else {
-				df.columns[i].Element(index).Set(v)
+			} else if err := df.columns[i].SetAt(index, v); err != nil {
+				return err
 			}
 		}
 	default:
-		return fmt.Errorf("type %T not supported", value)
+		return &series.TypeMismatchError{Want: "[]any | map[string]any", Got: fmt.Sprintf("%T", value)}
 	}
-	df.Size()
+	df.resize()
 	return nil
 }
 
 // AddRows 向列表末尾添加行
 func (df *DataFrame) AddRows(values [][]any) error {
-	if !equalLength(values, df.cols) {
-		return fmt.Errorf("length of columns must equal %d", df.cols)
+	if err := checkLength(values, df.cols); err != nil {
+		return err
 	}
 	for _, value := range values {
 		if err := df.Set(df.rows, value); err != nil {
@@ -364,43 +462,50 @@ func (df *DataFrame) AddRows(values [][]any) error {
 //	values：可选 series.Series []E {int | float64 | bool | string}
 //	defaultValue：当 values 长度不足时，自动添加
 func (df *DataFrame) AddCol(name string, values any, defaultValue any) error {
+	if value, ok := values.(series.Series); ok {
+		values = &value
+	}
 	var ns *series.Series
+	var err error
 	switch value := values.(type) {
 	case *series.Series:
+		ns = value.Copy()
 		// 补长度
-		if value.Len() < df.rows {
+		if ns.Len() < df.rows {
 			if defaultValue == nil {
-				return fmt.Errorf("length of default series must equal %d", df.rows)
+				return &series.LengthMismatchError{Want: df.rows, Got: ns.Len()}
 			}
-			if err := value.Append(data.CreateSlice(defaultValue, df.rows-value.Len())); err != nil {
+			if err := ns.Append(data.CreateSlice(defaultValue, df.rows-ns.Len())); err != nil {
 				return err
 			}
-		} else if value.Len() > df.rows {
-			value, _ = value.SubSet(data.Range(0, df.rows, 1)...)
+		} else if ns.Len() > df.rows && df.cols > 0 {
+			ns, _ = ns.SubSet(data.Range(0, df.rows, 1)...)
 		}
-		ns = value.Copy()
 	case []int:
-		if len(value) < df.rows {
-			value = append(value, data.CreateSlice(defaultValue.(int), df.rows-len(value))...)
+		if value, err = padSlice(value, defaultValue, df.rows); err == nil {
+			ns, err = series.NewSeries(value, series.Int, name)
 		}
-		ns, _ = series.NewSeries(value, series.Int, name)
 	case []string:
-		if len(value) < df.rows {
-			value = append(value, data.CreateSlice(defaultValue.(string), df.rows-len(value))...)
+		if value, err = padSlice(value, defaultValue, df.rows); err == nil {
+			ns, err = series.NewSeries(value, series.String, name)
 		}
-		ns, _ = series.NewSeries(value, series.String, name)
 	case []float64:
-		if len(value) < df.rows {
-			value = append(value, data.CreateSlice(defaultValue.(float64), df.rows-len(value))...)
+		if value, err = padSlice(value, defaultValue, df.rows); err == nil {
+			ns, err = series.NewSeries(value, series.Float, name)
 		}
-		ns, _ = series.NewSeries(value, series.Float, name)
 	case []bool:
-		if len(value) < df.rows {
-			value = append(value, data.CreateSlice(defaultValue.(bool), df.rows-len(value))...)
+		if value, err = padSlice(value, defaultValue, df.rows); err == nil {
+			ns, err = series.NewSeries(value, series.Bool, name)
+		}
+	case []time.Time:
+		if value, err = padSlice(value, defaultValue, df.rows); err == nil {
+			ns, err = series.NewSeries(value, series.Datetime, name)
 		}
-		ns, _ = series.NewSeries(value[:df.rows], series.Bool, name)
 	default:
-		return fmt.Errorf("type %T not supported", value)
+		return &series.TypeMismatchError{Want: "*series.Series | []int | []string | []float64 | []bool | []time.Time", Got: fmt.Sprintf("%T", value)}
+	}
+	if err != nil {
+		return err
 	}
 
 	// 更新或添加
@@ -410,72 +515,126 @@ func (df *DataFrame) AddCol(name string, values any, defaultValue any) error {
 	} else {
 		df.columns[indexCol] = *ns
 	}
-	df.Size()
+	df.resize()
 	return nil
 }
 
+// 以 defaultValue 将 value 补齐至 n 个元素
+func padSlice[E any](value []E, defaultValue any, n int) ([]E, error) {
+	if len(value) >= n {
+		return value, nil
+	}
+	v, ok := defaultValue.(E)
+	if !ok {
+		return nil, &series.TypeMismatchError{Want: fmt.Sprintf("%T", v), Got: fmt.Sprintf("%T", defaultValue)}
+	}
+	return append(value, data.CreateSlice(v, n-len(value))...), nil
+}
+
 // Concat 合并两个表
 //
 //	isColumn：是否合并在右侧 ，如果两个表列名相同，则更新原表列
 func (df *DataFrame) Concat(x DataFrame, isColumn bool) error {
+	return df.ConcatContext(context.Background(), nil, x, isColumn)
+}
+
+// ConcatContext 同 Concat，ctx 取消时返回 ctx.Err() 且不修改原表
+//
+//	progress: 进度回调，参数为已合并的行数，可为 nil
+func (df *DataFrame) ConcatContext(ctx context.Context, progress func(rows int), x DataFrame, isColumn bool) error {
 	if isColumn && df.rows != x.rows {
-		return fmt.Errorf("rows must equal %d", df.rows)
+		return &series.LengthMismatchError{Want: df.rows, Got: x.rows}
 	} else if !isColumn && df.cols != x.cols {
-		return fmt.Errorf("columns must equal %d", df.cols)
+		return &series.LengthMismatchError{Want: df.cols, Got: x.cols}
 	}
+	frame := df.Copy()
 	if isColumn {
 		for _, column := range x.columns {
-			if err := df.AddCol("", column, nil); err != nil {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			if err := frame.AddCol("", column, nil); err != nil {
 				return err
 			}
 		}
+		if progress != nil {
+			progress(x.rows)
+		}
 	} else {
 		n1, n2 := df.Names(), x.Names()
 		slices.Sort(n1)
 		slices.Sort(n2)
-		if !slices.Equal(n1, n2) {
-			return fmt.Errorf("两个表列名不相同")
+		if i := slices.IndexFunc(n1, func(s string) bool { return !slices.Contains(n2, s) }); i != -1 {
+			return &ColumnNotFoundError{Name: n1[i]}
 		}
-		for i, name := range df.Names() {
+		columns := make([]series.Series, 0, df.cols)
+		for _, name := range df.Names() {
 			ns, err := x.Columns(name)
 			if err != nil {
 				return err
 			}
-			if err := df.columns[i].Append(*ns.Copy()); err != nil {
+			columns = append(columns, ns)
+		}
+		// 分块追加，每块结束后检查 ctx 并报告进度
+		step := max(series.ProgressStep, x.rows/100)
+		for start := 0; start < x.rows; start += step {
+			if err := ctx.Err(); err != nil {
 				return err
 			}
+			rows := data.Range(start, min(start+step, x.rows), 1)
+			for i := range columns {
+				ns, err := columns[i].SubSet(rows...)
+				if err != nil {
+					return err
+				}
+				if err := frame.columns[i].Append(*ns); err != nil {
+					return err
+				}
+			}
+			if progress != nil {
+				progress(min(start+step, x.rows))
+			}
 		}
-		df.Size()
+		frame.resize()
 	}
+	*df = *frame
 	return nil
 }
 
 // DropCols 批量删除
 func (df *DataFrame) DropCols(names ...string) {
 	df.columns = slices.DeleteFunc(df.columns, func(s series.Series) bool { return slices.Contains(names, s.Name) })
-	df.Size()
+	df.resize()
 }
 
-// Rename 批量命名
+// Rename 批量命名，不存在的列名忽略
 func (df *DataFrame) Rename(cols map[string]string) {
-	for _, column := range df.columns {
+	for i, column := range df.columns {
 		if value, ok := cols[column.Name]; ok {
-			column.Name = value
-		} else {
-			fmt.Printf("column %s not found", column.Name)
+			df.columns[i].Name = value
 		}
 	}
 }
 
 // Arrange 排序
 func (df *DataFrame) Arrange(order ...Order) error {
+	return df.ArrangeContext(context.Background(), nil, order...)
+}
+
+// ArrangeContext 同 Arrange，ctx 取消时返回 ctx.Err() 且不修改原表
+//
+//	progress: 进度回调，参数为当前排序列已排序的行数，可为 nil
+func (df *DataFrame) ArrangeContext(ctx context.Context, progress func(rows int), order ...Order) error {
 	frame := df.Copy()
 	for _, o := range order {
 		i := slices.IndexFunc(frame.Names(), func(s string) bool { return s == o.ColumnName })
 		if i == -1 {
-			return fmt.Errorf("column %s not found", o.ColumnName)
+			return &ColumnNotFoundError{Name: o.ColumnName}
+		}
+		indexes, err := frame.columns[i].SortIndexContext(ctx, o.Reverse, progress)
+		if err != nil {
+			return err
 		}
-		indexes := frame.columns[i].SortIndex(o.Reverse)
 		for i := 0; i < frame.cols; i++ {
 			ns, _ := frame.columns[i].SubSet(indexes...)
 			ns.InitIndex()
@@ -495,7 +654,7 @@ func (df *DataFrame) SubSet(indexes ...int) (*DataFrame, error) {
 		}
 		frame.columns[i] = *set
 	}
-	frame.Size()
+	frame.resize()
 	return frame, nil
 }
 
@@ -554,44 +713,58 @@ type F struct {
 	OR       bool
 }
 
-// 判断切片长度是否相等
-func equalLength(values any, baseLen int) bool {
+// 判断切片或 map 中各元素长度是否相等，baseLen 为 0 时以第一个元素长度为准
+func checkLength(values any, baseLen int) error {
+	length := func(v any) (int, error) {
+		switch s := v.(type) {
+		case series.Series:
+			return s.Len(), nil
+		case *series.Series:
+			return s.Len(), nil
+		}
+		if rv := reflect.ValueOf(v); rv.Kind() == reflect.Slice {
+			return rv.Len(), nil
+		}
+		return 0, &series.TypeMismatchError{Want: "slice", Got: fmt.Sprintf("%T", v)}
+	}
+	var items []any
 	value := reflect.ValueOf(values)
-	if reflect.TypeOf(values).Kind() == reflect.Slice {
+	switch value.Kind() {
+	case reflect.Slice:
 		for i := 0; i < value.Len(); i++ {
-			var l int
-			if value.Index(i).Type().String() == "series.Series" {
-				l = int(value.Index(i).MethodByName("Len").Int())
-			} else {
-				l = reflect.ValueOf(value.Index(i).Interface()).Len()
-			}
-
-			if baseLen == 0 {
-				baseLen = l
-			}
-
-			if baseLen != l {
-				return false
-			}
+			items = append(items, value.Index(i).Interface())
 		}
-	} else if reflect.TypeOf(values).Kind() == reflect.Map {
+	case reflect.Map:
 		it := value.MapRange()
 		for it.Next() {
-			if baseLen == 0 {
-				baseLen = reflect.ValueOf(it.Value().Interface()).Len()
-			}
-			if reflect.ValueOf(it.Value().Interface()).Len() != baseLen {
-				return false
-			}
+			items = append(items, it.Value().Interface())
 		}
 	}
-
-	return true
+	for _, item := range items {
+		l, err := length(item)
+		if err != nil {
+			return err
+		}
+		if baseLen == 0 {
+			baseLen = l
+		}
+		if baseLen != l {
+			return &series.LengthMismatchError{Want: baseLen, Got: l}
+		}
+	}
+	return nil
 }
 
 func (df *DataFrame) Groups(names ...string) (map[string]*DataFrame, error) {
+	return df.GroupsContext(context.Background(), nil, names...)
+}
+
+// GroupsContext 同 Groups，ctx 取消时返回 ctx.Err()
+//
+//	progress: 进度回调，参数为已分组的行数，可为 nil，并行执行时不会被并发调用
+func (df *DataFrame) GroupsContext(ctx context.Context, progress func(rows int), names ...string) (map[string]*DataFrame, error) {
 	if names == nil {
-		return nil, fmt.Errorf("names is nil")
+		return nil, series.NewError(series.ErrInvalidArgument, MsgNamesNil)
 	}
 	var ns *series.Series
 	for i, name := range names {
@@ -614,37 +787,108 @@ func (df *DataFrame) Groups(names ...string) (map[string]*DataFrame, error) {
 		}
 	}
 
-	frame := df.Copy()
-	err := frame.AddCol("", ns, "")
-	if err != nil {
-		return nil, err
+	// 按首次出现的顺序收集各分组的行索引
+	var keys []string
+	indexes := make(map[string][]int)
+	for i, key := range ns.Records() {
+		if _, ok := indexes[key]; !ok {
+			keys = append(keys, key)
+		}
+		indexes[key] = append(indexes[key], i)
 	}
 
-	group := make(map[string]*DataFrame, 0)
-	for _, s := range slices.Compact(ns.Records()) {
-		df1, err := frame.Filter(F{
-			Column:   ns.Name,
-			Operator: series.Equal,
-			values:   s,
-			OR:       false,
-		})
-		if err != nil {
-			return nil, err
+	// 各分组按并行配置生成，先标记共享使并发的 SubSet 不写入原表
+	df.share()
+	frames := make([]*DataFrame, len(keys))
+	errs := make([]error, len(keys))
+	p := series.CurrentParallel()
+	p.ChunkSize = 1
+	report := newProgress(progress)
+	p.For(len(keys), func(start, end int) {
+		for k := start; k < end; k++ {
+			if err := ctx.Err(); err != nil {
+				errs[k] = err
+				return
+			}
+			frame := &DataFrame{columns: make([]series.Series, 0, df.cols)}
+			for i := range df.columns {
+				ns, err := df.columns[i].SubSet(indexes[keys[k]]...)
+				if err != nil {
+					errs[k] = err
+					return
+				}
+				frame.columns = append(frame.columns, *ns)
+			}
+			frame.resize()
+			frames[k] = frame
+			report.add(frame.rows)
 		}
-		df1.DropCols(ns.Name)
-		group[s] = df1
-	}
+	})
+	report.done()
 
+	group := make(map[string]*DataFrame, len(keys))
+	for k, key := range keys {
+		if errs[k] != nil {
+			return nil, errs[k]
+		}
+		group[key] = frames[k]
+	}
 	return group, nil
 }
 
 func (df *DataFrame) FormatCols(f func(index int, elem series.Element) series.Element, cols ...string) error {
 	for _, col := range cols {
-		column, err := df.Columns(col)
-		if err != nil {
-			return err
+		i := slices.IndexFunc(df.columns, func(s series.Series) bool { return s.Name == col })
+		if i == -1 {
+			return &ColumnNotFoundError{Name: col}
 		}
-		column.Format(f)
+		df.columns[i].Format(f)
 	}
 	return nil
 }
+
+// Explode 展开列表列，列表中每个元素生成一行，其余列的值随之复制
+//
+//	col: 列表类型的列名，展开后该列类型为列表元素类型，空列表或空值保留一行空值
+func (df *DataFrame) Explode(col string) (*DataFrame, error) {
+	i := slices.IndexFunc(df.columns, func(s series.Series) bool { return s.Name == col })
+	if i == -1 {
+		return nil, &ColumnNotFoundError{Name: col}
+	}
+	ns, indexes, err := df.columns[i].Explode()
+	if err != nil {
+		return nil, err
+	}
+	if len(indexes) == 0 {
+		frame := df.Copy()
+		frame.columns[i] = *ns
+		return frame, nil
+	}
+	frame, err := df.SubSet(indexes...)
+	if err != nil {
+		return nil, err
+	}
+	frame.columns[i] = *ns
+	return frame, nil
+}
+
+// Extract 用正则表达式提取列中内容，返回每个分组一列的新表
+//
+//	col: 列名
+//	pattern: 正则表达式，命名分组以分组名作为列名，其余以分组序号（从 0 开始）作为列名
+func (df *DataFrame) Extract(col, pattern string) (*DataFrame, error) {
+	column, err := df.Columns(col)
+	if err != nil {
+		return nil, err
+	}
+	extract, err := column.Str().Extract(pattern)
+	if err != nil {
+		return nil, err
+	}
+	frame := &DataFrame{columns: make([]series.Series, 0, len(extract))}
+	for _, s := range extract {
+		frame.columns = append(frame.columns, *s)
+	}
+	frame.resize()
+	return frame, nil
+}
@@ -1,10 +1,24 @@
 package dataframe
 
 import (
+	"bytes"
+	"compress/zlib"
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"gitee.com/jn-qq/go-tools/data"
-	"gitee.com/jn-qq/go-tools/pandas/series"
+	"gitee.com/jn-qq/pandas/internal/arrow"
+	"gitee.com/jn-qq/pandas/internal/parquet"
+	"gitee.com/jn-qq/pandas/series"
+	"math"
+	"os"
+	"path/filepath"
 	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"testing/fstest"
 )
 
 func ExampleLoadMap() {
@@ -519,3 +533,666 @@ func ExampleDataFrame_FormatCols() {
 	//+-------+--------+-------------+
 
 }
+
+func ExampleDataFrame_Explode() {
+	df, _ := New(
+		[]any{[]string{"Join", "Mary", "Andy"}, [][]string{{"A1", "B2"}, {}, {"C3"}}},
+		[]string{"name", "sku"},
+	)
+	frame, _ := df.Explode("sku")
+	fmt.Println(frame)
+	// output:+-------------------------+
+	//|  DataFrame Size：2 x 4  |
+	//+-------+--------+--------+
+	//| Index | name   | sku    |
+	//+-------+--------+--------+
+	//| 1     | Join   | A1     |
+	//| 2     | Join   | B2     |
+	//| 3     | Mary   | NaN    |
+	//| 4     | Andy   | C3     |
+	//+-------+--------+--------+
+	//| Types | string | string |
+	//+-------+--------+--------+
+
+}
+
+func ExampleDataFrame_Extract() {
+	df, _ := New(
+		[]any{[]string{"Join", "Mary"}, []string{"电话 13800138000", "tel:021-5555"}},
+		[]string{"name", "memo"},
+	)
+	frame, _ := df.Extract("memo", `(?P<area>\d{3})-?(?P<number>\d+)`)
+	fmt.Println(frame)
+	// output:+---------------------------+
+	//|   DataFrame Size：2 x 2   |
+	//+-------+--------+----------+
+	//| Index | area   | number   |
+	//+-------+--------+----------+
+	//| 1     | 138    | 00138000 |
+	//| 2     | 021    | 5555     |
+	//+-------+--------+----------+
+	//| Types | string | string   |
+	//+-------+--------+----------+
+
+}
+
+func ExampleReadCSV_errors() {
+	p := filepath.Join(os.TempDir(), "pandas_errors.csv")
+	_ = os.WriteFile(p, []byte("name,amount\nJoin,\"1,234\"\nMary,3.2万\nAndy,--\n"), 0644)
+	defer os.Remove(p)
+
+	var errs CellErrors
+	df, _ := ReadCSV(p, Sheets{
+		SheetName: "amount.csv",
+		ColsType:  []series.Type{series.String, series.Float},
+		Errors:    &errs,
+	})
+	fmt.Println(df.Records(false, true)[1])
+	for _, err := range errs {
+		fmt.Println(err)
+	}
+	// output:
+	//[amount 1234 32000 NaN]
+	//amount.csv 第 4 行第 2 列（amount）"--" 不能转换为 float64
+}
+
+func ExampleLoadRecord_infer() {
+	df, _ := LoadRecord(
+		[][]string{
+			{"name", "zip", "amount", "rate", "vip", "joined"},
+			{"Join", "010020", "1,200", "12.5%", "true", "2024-01-02"},
+			{"Mary", "200001", "3万", "NaN", "false", "2024-03-15 09:30:00"},
+		},
+		nil, nil,
+	)
+	fmt.Println(df.Types())
+	// output:
+	//[string string int float64 bool datetime]
+}
+
+func ExampleReadCSV_naValues() {
+	p := filepath.Join(os.TempDir(), "pandas_na.csv")
+	_ = os.WriteFile(p, []byte("name,amount,memo\nJoin,-,\nMary,12,无\nAndy,N/A,ok\n"), 0644)
+	defer os.Remove(p)
+
+	df, _ := ReadCSV(p, Sheets{
+		NAValues:    []string{"-", "N/A"},
+		ColNAValues: map[string][]string{"memo": {"无"}},
+		KeepEmpty:   true,
+	})
+	fmt.Println(df.Types())
+	fmt.Printf("%q\n", df.Records(false, false))
+	// output:
+	//[string int string]
+	//[["Join" "Mary" "Andy"] ["NaN" "12" "NaN"] ["" "NaN" "ok"]]
+}
+
+func ExampleReadCSV_strict() {
+	p := filepath.Join(os.TempDir(), "pandas_strict.csv")
+	_ = os.WriteFile(p, []byte("name,amount\nJoin,12\nMary,abc\nAndy,x1\n"), 0644)
+	defer os.Remove(p)
+
+	df, err := ReadCSV(p, Sheets{
+		SheetName: "strict.csv",
+		ColsType:  []series.Type{series.String, series.Int},
+		Strict:    true,
+	})
+	fmt.Println(df == nil)
+	fmt.Println(err)
+	// output:
+	//true
+	//strict.csv 第 3 行第 2 列（amount）"abc" 不能转换为 int
+	//strict.csv 第 4 行第 2 列（amount）"x1" 不能转换为 int
+}
+
+func ExampleDataFrame_Arrange_errors() {
+	df, _ := New([]any{[]string{"Join", "Mary"}, []int{12, 15}}, []string{"name", "age"})
+	err := df.Arrange(SortByForward("sex"))
+	var notFound *ColumnNotFoundError
+	fmt.Println(errors.Is(err, ErrColumnNotFound), errors.As(err, &notFound) && notFound.Name == "sex")
+
+	err = df.AddRows([][]any{{"Andy"}})
+	fmt.Println(errors.Is(err, series.ErrLengthMismatch), err)
+
+	p := filepath.Join(os.TempDir(), "pandas_errors.csv")
+	_ = os.WriteFile(p, []byte("name,amount\nJoin,abc\n"), 0644)
+	defer os.Remove(p)
+	_, err = ReadCSV(p, Sheets{ColsType: []series.Type{series.String, series.Int}, Strict: true})
+	var cellErr *CellError
+	fmt.Println(errors.Is(err, series.ErrParse), errors.As(err, &cellErr) && cellErr.Row == 2)
+	// output:
+	//true true
+	//true 长度不相等：期望 2，实际 1
+	//true true
+}
+
+func ExampleDataFrame_PrintIn() {
+	df, _ := New([]any{[]string{"Join", "Mary"}, []int{12, 15}}, []string{"name", "age"})
+	fmt.Print(df.PrintIn(false, series.En))
+	_, err := df.Columns("sex")
+	fmt.Println(series.Localize(err, series.En))
+	fmt.Println(series.Localize(err, series.Zh))
+	// output:
+	//+-----------------------+
+	//| DataFrame Size: 2 x 2 |
+	//+-------+--------+------+
+	//| Index | name   | age  |
+	//+-------+--------+------+
+	//| 1     | Join   | 12   |
+	//| 2     | Mary   | 15   |
+	//+-------+--------+------+
+	//| Types | string | int  |
+	//+-------+--------+------+
+	//column sex not found
+	//列 sex 不存在
+}
+
+func TestMessagesTranslated(t *testing.T) {
+	// 表格标题沿用原有的英文输出
+	same := map[string]bool{MsgPrintEmpty: true, MsgPrintIndex: true, MsgPrintTypes: true}
+	keys := []string{
+		MsgColumnNotFound, MsgEmptyData, MsgColumnNotFoundDetail, MsgCellError, MsgNamesNil,
+		MsgPrintTitle, MsgPrintEmpty, MsgPrintIndex, MsgPrintTypes, MsgUnknownEncoding, MsgCodecNoWriter,
+		MsgUnknownOrient, MsgNDJSONLine, MsgUnknownCodec, MsgJSONSyntax, MsgJSONUnexpected,
+	}
+	for _, key := range keys {
+		zh, en := series.Message(series.Zh, key), series.Message(series.En, key)
+		if zh == key || en == key {
+			t.Errorf("%s: missing message, zh %q, en %q", key, zh, en)
+		} else if zh == en && !same[key] {
+			t.Errorf("%s: zh message %q is not translated", key, zh)
+		}
+	}
+}
+
+func ExampleDataFrame_Copy() {
+	df, _ := New([]any{[]string{"Join", "Mary"}, []int{12, 15}}, []string{"name", "age"})
+	frame := df.Copy()
+	_ = frame.Set(0, []any{"Andy", 20})
+	_ = frame.FormatCols(func(index int, elem series.Element) series.Element {
+		elem.Set(elem.Int() + 1)
+		return elem
+	}, "age")
+	sub, _ := df.SubSet(1)
+	_ = sub.Set(0, map[string]any{"name": "Lily"})
+
+	fmt.Println(df.Records(false, false))
+	fmt.Println(frame.Records(false, false))
+	fmt.Println(sub.Records(false, false))
+	// output:
+	//[[Join Mary] [12 15]]
+	//[[Andy Mary] [21 16]]
+	//[[Lily] [15]]
+}
+
+func ExampleDataFrame_Columns() {
+	df, _ := New([]any{[]string{"Join", "Mary"}, []int{12, 15}}, []string{"name", "age"})
+	age, _ := df.Columns("age")
+	frame := df.Copy()
+	_ = age.SetAt(0, 99)
+	age.Element(1).Set(98)
+
+	fmt.Println(age.Records())
+	fmt.Println(df.Records(false, false))
+	fmt.Println(frame.Records(false, false))
+	// output:
+	//[99 98]
+	//[[Join Mary] [12 15]]
+	//[[Join Mary] [12 15]]
+}
+
+func ExampleSyncDataFrame() {
+	df, _ := New([]any{[]string{"Join"}, []int{12}}, []string{"name", "age"})
+	sdf := NewSync(df)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(2)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 50; j++ {
+				_ = sdf.AddRows([][]any{{"Mary" + strconv.Itoa(i), j}})
+				_ = sdf.Set(0, map[string]any{"age": j})
+			}
+		}(i)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 50; j++ {
+				_ = sdf.Records(true, true)
+				_ = sdf.Print(false)
+				snapshot := sdf.Snapshot()
+				_ = snapshot.Set(0, []any{"Andy", 1})
+				_, _ = sdf.Filter(F{Column: "age", Operator: series.GreaterThan, values: 10})
+				if elem, err := sdf.Cell(0, "age"); err == nil {
+					elem.Set(-1)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	fmt.Println(sdf.Size())
+	elem, _ := sdf.Cell(0, "name")
+	fmt.Println(elem)
+	// output:
+	//2 201
+	//Join
+}
+
+func ExampleSyncDataFrame_Read() {
+	df, _ := New([]any{[]string{"Join", "Mary"}, []int{12, 15}}, []string{"name", "age"})
+	sdf := NewSync(df)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 50; j++ {
+				sdf.Read(func(df *DataFrame) {
+					df.Rows(0)["age"].Set(-1)
+					df.Cell(1, "name").Set("Andy")
+					_ = df.Set(1, map[string]any{"age": -1})
+				})
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 50; j++ {
+				_ = sdf.Write(func(df *DataFrame) error {
+					df.Rows(1)["age"].Set(15)
+					return nil
+				})
+			}
+		}()
+	}
+	wg.Wait()
+	fmt.Println(sdf.Records(false, false))
+	// output:
+	//[[Join Mary] [12 15]]
+}
+
+func ExampleReadCSVContext() {
+	p := filepath.Join(os.TempDir(), "pandas_context.csv")
+	var b strings.Builder
+	b.WriteString("id,name\n")
+	for i := 0; i < 2500; i++ {
+		b.WriteString(strconv.Itoa(i) + ",n" + strconv.Itoa(i) + "\n")
+	}
+	_ = os.WriteFile(p, []byte(b.String()), 0644)
+	defer os.Remove(p)
+
+	df, _ := ReadCSVContext(context.Background(), p, Sheets{
+		Progress: func(rows int) { fmt.Println("read", rows) },
+	})
+	fmt.Println(df.Size())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := ReadCSVContext(ctx, p, Sheets{})
+	fmt.Println(errors.Is(err, context.Canceled))
+
+	err = df.ConcatContext(ctx, nil, *df, false)
+	fmt.Println(errors.Is(err, context.Canceled), df.NRows())
+	// output:
+	//read 1000
+	//read 2000
+	//read 2500
+	//2 2500
+	//true
+	//true 2500
+}
+
+func ExampleReadCSVChunks() {
+	p := filepath.Join(os.TempDir(), "pandas_chunks.csv")
+	_ = os.WriteFile(p, []byte("id,score\n1,90\n2,85.5\n3,70\n4,\n5,60\n"), 0644)
+	defer os.Remove(p)
+
+	chunks, err := ReadCSVChunks(p, Sheets{}, 2)
+	if err != nil {
+		panic(err)
+	}
+	defer chunks.Close()
+	for chunks.Next() {
+		df := chunks.DataFrame()
+		fmt.Println(df.Types(), df.Records(false, false))
+	}
+	fmt.Println(chunks.Err())
+	// output:
+	//[int float64] [[1 2] [90 85.5]]
+	//[int float64] [[3 4] [70 NaN]]
+	//[int float64] [[5] [60]]
+	//<nil>
+}
+
+func ExampleReadCSVFrom() {
+	df, _ := ReadCSVFrom(strings.NewReader("name,age\nJoin,12\nMary,15\n"), Sheets{})
+
+	var csvBuf bytes.Buffer
+	_ = df.WriteCSVTo(&csvBuf)
+	fmt.Printf("%q\n", csvBuf.String())
+
+	var xlsxBuf bytes.Buffer
+	_ = df.WriteXLSXTo(&xlsxBuf, "data")
+	frames, _ := ReadXLSXFrom(&xlsxBuf, Sheets{SheetName: "data"})
+	fmt.Println(frames["data"].Records(false, true))
+
+	fsys := fstest.MapFS{"data/people.csv": {Data: []byte("name,age\nAndy,20\n")}}
+	df, _ = ReadCSVFS(fsys, "data/people.csv", Sheets{})
+	fmt.Println(df.Records(true, true))
+	// output:
+	//"\ufeffname,age\nJoin,12\nMary,15\n"
+	//[[name Join Mary] [age 12 15]]
+	//[[name age] [Andy 20]]
+}
+
+func ExampleCSVDialect() {
+	data := "\ufeff# 注释\nname;score\nJoin; 1.5\n;\nMary; 2.25\n"
+	df, _ := ReadCSVFrom(strings.NewReader(data), Sheets{
+		Dialect: CSVDialect{Comma: ';', Comment: '#', TrimLeadingSpace: true, SkipBlankLines: true},
+	})
+	fmt.Println(df.Records(true, true))
+
+	var buf bytes.Buffer
+	_ = df.WriteCSVTo(&buf, CSVDialect{Comma: '\t', NoBOM: true, UseCRLF: true, FloatFormat: 'f', FloatPrecision: 2})
+	fmt.Printf("%q\n", buf.String())
+	// output:
+	//[[name score] [Join 1.5] [Mary 2.25]]
+	//"name\tscore\r\nJoin\t1.50\r\nMary\t2.25\r\n"
+}
+
+func ExampleEncoding() {
+	df, _ := New([]any{[]string{"张三", "李四"}, []int{18, 20}}, []string{"姓名", "年龄"})
+
+	var buf bytes.Buffer
+	_ = df.WriteCSVTo(&buf, CSVDialect{Encoding: GBK})
+	fmt.Printf("%x\n", buf.Bytes()[:4])
+
+	gbk, _ := ReadCSVFrom(bytes.NewReader(buf.Bytes()), Sheets{Dialect: CSVDialect{Encoding: AutoDetect}})
+	fmt.Println(gbk.Records(true, true))
+
+	buf.Reset()
+	_ = df.WriteCSVTo(&buf, CSVDialect{Encoding: UTF16})
+	utf16, _ := ReadCSVFrom(&buf, Sheets{Dialect: CSVDialect{Encoding: AutoDetect}})
+	fmt.Println(utf16.Records(true, true))
+
+	_, err := ReadCSVFrom(&buf, Sheets{Dialect: CSVDialect{Encoding: "latin1"}})
+	fmt.Println(errors.Is(err, series.ErrInvalidArgument))
+	// output:
+	//d0d5c3fb
+	//[[姓名 年龄] [张三 18] [李四 20]]
+	//[[姓名 年龄] [张三 18] [李四 20]]
+	//true
+}
+
+func ExampleRegisterCodec() {
+	df, _ := New([]any{[]string{"Join", "Mary"}, []int{12, 15}}, []string{"name", "age"})
+
+	p := filepath.Join(os.TempDir(), "pandas_codec.csv.gz")
+	defer os.Remove(p)
+	_ = df.WriteToCSV(p)
+	head, _ := os.ReadFile(p)
+	fmt.Printf("%x\n", head[:2])
+	gz, _ := ReadCSV(p, Sheets{})
+	fmt.Println(gz.Records(true, true))
+
+	var buf bytes.Buffer
+	w := zlib.NewWriter(&buf)
+	_ = df.WriteCSVTo(w)
+	_ = w.Close()
+	zz, _ := ReadCSVFrom(&buf, Sheets{})
+	fmt.Println(zz.Records(true, true))
+	// output:
+	//1f8b
+	//[[name age] [Join 12] [Mary 15]]
+	//[[name age] [Join 12] [Mary 15]]
+}
+
+func ExampleReadCSVFrom_zlib() {
+	var buf bytes.Buffer
+	w, _ := zlib.NewWriterLevel(&buf, 2)
+	_, _ = w.Write([]byte("name,age\nJoin,12\n"))
+	_ = w.Close()
+	fmt.Printf("%x ", buf.Bytes()[:2])
+	zz, _ := ReadCSVFrom(&buf, Sheets{})
+	fmt.Println(zz.Records(true, true))
+
+	// 以 x 开头但不是合法 zlib 文件头的数据原样读取
+	plain, err := ReadCSVFrom(strings.NewReader("xy,z\n1,2\n"), Sheets{})
+	fmt.Println(plain.Records(true, true), err)
+	// output:
+	//785e [[name age] [Join 12]]
+	//[[xy z] [1 2]] <nil>
+}
+
+func ExampleReadJSONFrom() {
+	data := `[{"name":"Join","age":12,"score":90.0},{"name":"Mary","score":null,"tags":["a"]}]`
+	df, _ := ReadJSONFrom(strings.NewReader(data), JSONOptions{})
+	fmt.Println(df.Names(), df.Types())
+	fmt.Println(df.Records(true, false))
+
+	for _, orient := range []JSONOrient{OrientRecords, OrientColumns, OrientSplit, OrientValues} {
+		var buf bytes.Buffer
+		_ = df.WriteJSONTo(&buf, JSONOptions{Orient: orient})
+		fmt.Println(buf.String())
+	}
+
+	b, _ := json.Marshal(df)
+	var ndf DataFrame
+	_ = json.Unmarshal(b, &ndf)
+	fmt.Println(ndf.Types())
+	// output:
+	//[name age score tags] [string int float64 []string]
+	//[[Join 12 90 NaN] [Mary NaN NaN ["a"]]]
+	//[{"name":"Join","age":12,"score":90.0,"tags":null},{"name":"Mary","age":null,"score":null,"tags":["a"]}]
+	//{"name":["Join","Mary"],"age":[12,null],"score":[90.0,null],"tags":[null,["a"]]}
+	//{"columns":["name","age","score","tags"],"types":["string","int","float64","[]string"],"data":[["Join",12,90.0,null],["Mary",null,null,["a"]]]}
+	//[["Join",12,90.0,null],["Mary",null,null,["a"]]]
+	//[string int float64 []string]
+}
+
+func ExampleReadJSONFrom_bool() {
+	df, _ := ReadJSONFrom(strings.NewReader(`{"id":[1,2,3],"pass":[true,null,false]}`), JSONOptions{Orient: OrientColumns})
+	fmt.Println(df.Types(), df.Records(false, false))
+	var buf bytes.Buffer
+	_ = df.WriteJSONTo(&buf, JSONOptions{Orient: OrientColumns})
+	fmt.Println(buf.String())
+	// output:
+	//[int bool] [[1 2 3] [true false false]]
+	//{"id":[1,2,3],"pass":[true,false,false]}
+}
+
+func ExampleReadNDJSONFrom() {
+	data := `{"id":1,"user":{"name":"Join","age":12}}
+{"id":2,"user":{"name":"Mary"},"level":"warn"}
+
+{"id":3,"level":"error"}
+`
+	df, _ := ReadNDJSONFrom(strings.NewReader(data), JSONOptions{})
+	fmt.Println(df.Names(), df.Types())
+	fmt.Println(df.Records(true, false))
+
+	var buf bytes.Buffer
+	_ = df.WriteNDJSONTo(&buf)
+	fmt.Print(buf.String())
+
+	_, err := ReadNDJSONFrom(strings.NewReader("{\"id\":1}\n[1]\n"), JSONOptions{})
+	fmt.Println(err)
+	// output:
+	//[id user.name user.age level] [int string int string]
+	//[[1 Join 12 NaN] [2 Mary NaN warn] [3 NaN NaN error]]
+	//{"id":1,"user.name":"Join","user.age":12,"level":null}
+	//{"id":2,"user.name":"Mary","user.age":null,"level":"warn"}
+	//{"id":3,"user.name":null,"user.age":null,"level":"error"}
+	//JSON 格式错误：第 2 行（偏移 10）: 期望 {，实际 [
+}
+
+func ExampleJSONSyntaxError() {
+	_, err := ReadJSONFrom(strings.NewReader("[\n  {\"id\":1},\n  [2]\n]"), JSONOptions{})
+	fmt.Println(err)
+	_, err = ReadJSONFrom(strings.NewReader("{\"id\":[1,\n2,]}"), JSONOptions{Orient: OrientColumns})
+	fmt.Println(err)
+
+	_, err = ReadNDJSONFrom(strings.NewReader("{\"id\":1}\n{\"id\":2,}\n"), JSONOptions{})
+	var syntaxErr *JSONSyntaxError
+	fmt.Println(errors.As(err, &syntaxErr), syntaxErr.Line, syntaxErr.Offset, errors.Is(err, series.ErrParse))
+	_, err = ReadNDJSONFrom(strings.NewReader("{\"id\":1} 2\n"), JSONOptions{})
+	fmt.Println(err)
+	// output:
+	//JSON 格式错误：第 3 行（偏移 17）: 期望 {，实际 [
+	//JSON 格式错误：第 2 行（偏移 13）: invalid character ']' after object key:value pair
+	//true 2 17 true
+	//JSON 格式错误：第 1 行（偏移 10）: 期望 EOF，实际 2
+}
+
+func ExampleReadNDJSONFrom_bool() {
+	df, _ := ReadNDJSONFrom(strings.NewReader("{\"id\":1,\"pass\":true}\n{\"id\":2,\"pass\":null}\n{\"id\":3}\n"), JSONOptions{})
+	fmt.Println(df.Types())
+	var buf bytes.Buffer
+	_ = df.WriteNDJSONTo(&buf)
+	fmt.Print(buf.String())
+	df, _ = ReadNDJSONFrom(&buf, JSONOptions{})
+	fmt.Println(df.Records(true, true))
+	// output:
+	//[int bool]
+	//{"id":1,"pass":true}
+	//{"id":2,"pass":false}
+	//{"id":3,"pass":false}
+	//[[id pass] [1 true] [2 false] [3 false]]
+}
+
+func ExampleReadParquet() {
+	df, _ := New([]any{
+		[]string{"Join", "Mary", "NaN"},
+		[]int{12, 15, math.MinInt},
+		[]float64{90.5, math.NaN(), 70},
+		[]bool{true, false, true},
+		[][]string{{"a", "b"}, {}, {}},
+	}, []string{"name", "age", "score", "pass", "tags"})
+
+	p := filepath.Join(os.TempDir(), "pandas.parquet")
+	defer os.Remove(p)
+	_ = df.WriteParquet(p, ParquetOptions{RowGroupSize: 2})
+
+	all, _ := ReadParquet(p, ParquetOptions{})
+	fmt.Println(all.Types())
+	fmt.Println(all.Records(true, true))
+
+	groups, _ := ReadParquetRowGroups(p, ParquetOptions{Columns: []string{"tags", "age"}})
+	defer groups.Close()
+	for groups.Next() {
+		fmt.Println(groups.DataFrame().Records(true, true))
+	}
+	// output:
+	//[string int float64 bool []string]
+	//[[name age score pass tags] [Join 12 90.5 true ["a","b"]] [Mary 15 NaN false []] [NaN NaN 70 true []]]
+	//[[tags age] [["a","b"] 12] [[] 15]]
+	//[[tags age] [[] NaN]]
+}
+
+func ExampleReadParquetFrom_bool() {
+	var buf bytes.Buffer
+	pw, _ := parquet.NewWriter(&buf, []parquet.Field{{Name: "pass", Kind: parquet.KindBool}}, parquet.Uncompressed, nil)
+	_ = pw.WriteRowGroup([][]any{{true, nil, false}})
+	_ = pw.Close()
+	df, _ := ReadParquetFrom(bytes.NewReader(buf.Bytes()), int64(buf.Len()), ParquetOptions{})
+	fmt.Println(df.Types(), df.Records(true, true))
+
+	buf.Reset()
+	_ = df.WriteParquetTo(&buf, ParquetOptions{})
+	pf, _ := parquet.Open(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	values, _ := pf.ReadColumn(0, pf.Columns()[0])
+	fmt.Println(values)
+	// output:
+	//[bool] [[pass] [true] [false] [false]]
+	//[true false false]
+}
+
+func ExampleReadParquetFrom_strings() {
+	var buf bytes.Buffer
+	pw, _ := parquet.NewWriter(&buf, []parquet.Field{{Name: "memo", Kind: parquet.KindString}}, parquet.Uncompressed, nil)
+	_ = pw.WriteRowGroup([][]any{{"NaN", "", nil, "null"}})
+	_ = pw.Close()
+	df, _ := ReadParquetFrom(bytes.NewReader(buf.Bytes()), int64(buf.Len()), ParquetOptions{})
+	memo, _ := df.Columns("memo")
+	fmt.Printf("%#v\n", memo.JSONValues())
+
+	buf.Reset()
+	_ = df.WriteParquetTo(&buf, ParquetOptions{})
+	pf, _ := parquet.Open(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	values, _ := pf.ReadColumn(0, pf.Columns()[0])
+	fmt.Printf("%#v\n", values)
+
+	buf.Reset()
+	_ = df.WriteArrowTo(&buf, ArrowOptions{Stream: true})
+	df, _ = ReadArrowFrom(&buf, ArrowOptions{})
+	memo, _ = df.Columns("memo")
+	fmt.Printf("%#v\n", memo.JSONValues())
+	// output:
+	//[]interface {}{"NaN", "", interface {}(nil), "null"}
+	//[]interface {}{"NaN", "", interface {}(nil), "null"}
+	//[]interface {}{"NaN", "", interface {}(nil), "null"}
+}
+
+func ExampleReadArrowFrom() {
+	df, _ := New([]any{
+		[]string{"Join", "Mary", "NaN"},
+		[]int{12, 15, math.MinInt},
+		[]float64{90.5, math.NaN(), 70},
+		[][]int{{1, 2}, {}, {3}},
+	}, []string{"name", "age", "score", "ids"})
+
+	var buf bytes.Buffer
+	_ = df.WriteArrowTo(&buf, ArrowOptions{})
+	file, _ := ReadArrowFrom(&buf, ArrowOptions{})
+	fmt.Println(file.Types())
+	fmt.Println(file.Records(true, true))
+
+	buf.Reset()
+	_ = df.WriteArrowTo(&buf, ArrowOptions{Stream: true, BatchSize: 2})
+	stream, _ := ReadArrowFrom(&buf, ArrowOptions{Columns: []string{"score", "name"}})
+	fmt.Println(stream.Records(true, true))
+	// output:
+	//[string int float64 []int]
+	//[[name age score ids] [Join 12 90.5 [1,2]] [Mary 15 NaN []] [NaN NaN 70 [3]]]
+	//[[score name] [90.5 Join] [NaN Mary] [70 NaN]]
+}
+
+func ExampleLoad() {
+	payload := math.Float64frombits(0x7ff8000000000abc)
+	df, _ := New([]any{
+		[]string{"Join", "", "NaN"},
+		[]int{12, 15, math.MinInt},
+		[]float64{90.5, payload, math.Inf(-1)},
+		[][]string{{"a", "b"}, {}, nil},
+	}, []string{"name", "age", "score", "tags"})
+
+	var buf bytes.Buffer
+	_ = df.Save(&buf)
+	loaded, _ := Load(&buf)
+	fmt.Println(loaded.Types())
+	fmt.Println(loaded.Records(true, true))
+	score, _ := loaded.Columns("score")
+	fmt.Printf("%#x\n", math.Float64bits(score.Float()[1]))
+	// output:
+	//[string int float64 []string]
+	//[[name age score tags] [Join 12 90.5 ["a","b"]] [NaN 15 NaN []] [NaN NaN -Inf []]]
+	//0x7ff8000000000abc
+}
+
+func ExampleReadArrowFrom_bool() {
+	var buf bytes.Buffer
+	aw, _ := arrow.NewStreamWriter(&buf, []arrow.Field{{Name: "pass", Kind: arrow.KindBool}}, "", nil)
+	_ = aw.WriteBatch([][]any{{true, nil, false}})
+	_ = aw.Close()
+	df, _ := ReadArrowFrom(&buf, ArrowOptions{})
+	fmt.Println(df.Types(), df.Records(true, true))
+
+	_ = df.WriteArrowTo(&buf, ArrowOptions{Stream: true})
+	ar, _ := arrow.NewStreamReader(&buf)
+	batch, _ := ar.Next()
+	values, _ := batch.Column(ar.Columns()[0])
+	fmt.Println(values)
+	// output:
+	//[bool] [[pass] [true] [false] [false]]
+	//[true false false]
+}
@@ -0,0 +1,119 @@
+/**
+  Copyright (c) [2024] [JiangNan]
+  [pandas] is licensed under Mulan PSL v2.
+  You can use this software according to the terms and conditions of the Mulan PSL v2.
+  You may obtain a copy of Mulan PSL v2 at:
+           http://license.coscl.org.cn/MulanPSL2
+  THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND, EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT, MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+  See the Mulan PSL v2 for more details.
+*/
+
+package dataframe
+
+import (
+	"bufio"
+	"bytes"
+	"gitee.com/jn-qq/pandas/series"
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/simplifiedchinese"
+	"golang.org/x/text/encoding/traditionalchinese"
+	"golang.org/x/text/encoding/unicode"
+	"golang.org/x/text/transform"
+	"io"
+	"unicode/utf8"
+)
+
+// Encoding CSV 文件编码
+type Encoding string
+
+const (
+	UTF8    Encoding = "utf-8"   // UTF-8，默认编码
+	UTF16   Encoding = "utf-16"  // UTF-16，读取时按 BOM 判断字节序（无 BOM 按小端），写入时为带 BOM 的小端
+	GBK     Encoding = "gbk"     // GBK，旧版中文系统及 Excel 导出的 CSV 常用编码
+	GB18030 Encoding = "gb18030" // GB18030，兼容 GBK
+	Big5    Encoding = "big5"    // Big5，繁体中文
+	// AutoDetect 读取时自动识别：根据 BOM 识别 UTF-8、UTF-16，其余内容为合法 UTF-8 时按 UTF-8，
+	// 否则按 GB18030 读取（Big5 无法可靠识别，需显式指定）；写入时同 UTF8
+	AutoDetect Encoding = "auto"
+)
+
+// 自动识别编码时检查的字节数
+const detectSize = 4096
+
+// 返回编码对应的 encoding.Encoding，UTF-8 返回 nil
+func (e Encoding) encoding() (encoding.Encoding, error) {
+	switch e {
+	case "", UTF8, AutoDetect:
+		return nil, nil
+	case UTF16:
+		return unicode.UTF16(unicode.LittleEndian, unicode.UseBOM), nil
+	case GBK:
+		return simplifiedchinese.GBK, nil
+	case GB18030:
+		return simplifiedchinese.GB18030, nil
+	case Big5:
+		return traditionalchinese.Big5, nil
+	default:
+		return nil, series.NewError(series.ErrInvalidArgument, MsgUnknownEncoding, string(e))
+	}
+}
+
+// 返回将 r 按编码转换为 UTF-8 的读取器
+func (e Encoding) newReader(r io.Reader) (io.Reader, error) {
+	if e == AutoDetect {
+		br := bufio.NewReaderSize(r, detectSize)
+		head, _ := br.Peek(detectSize)
+		e = detectEncoding(head, len(head) == detectSize)
+		r = br
+	}
+	enc, err := e.encoding()
+	if err != nil || enc == nil {
+		return r, err
+	}
+	return transform.NewReader(r, enc.NewDecoder()), nil
+}
+
+// 返回将 UTF-8 按编码写入 w 的写入器，写入完毕后需调用 Close
+func (e Encoding) newWriter(w io.Writer) (io.WriteCloser, error) {
+	enc, err := e.encoding()
+	if err != nil {
+		return nil, err
+	}
+	if enc == nil {
+		return nopWriteCloser{w}, nil
+	}
+	return transform.NewWriter(w, enc.NewEncoder()), nil
+}
+
+// 根据文件开头内容识别编码，truncated 表示 head 之后还有数据
+func detectEncoding(head []byte, truncated bool) Encoding {
+	switch {
+	case bytes.HasPrefix(head, []byte("\xEF\xBB\xBF")):
+		return UTF8
+	case bytes.HasPrefix(head, []byte("\xFF\xFE")), bytes.HasPrefix(head, []byte("\xFE\xFF")):
+		return UTF16
+	}
+	if truncated {
+		// 去除末尾被截断的字符
+		for i := len(head) - 1; i >= 0 && i >= len(head)-utf8.UTFMax; i-- {
+			if utf8.RuneStart(head[i]) {
+				if !utf8.FullRune(head[i:]) {
+					head = head[:i]
+				}
+				break
+			}
+		}
+	}
+	if utf8.Valid(head) {
+		return UTF8
+	}
+	return GB18030
+}
+
+type nopWriteCloser struct {
+	io.Writer
+}
+
+func (nopWriteCloser) Close() error {
+	return nil
+}
@@ -0,0 +1,124 @@
+/**
+  Copyright (c) [2024] [JiangNan]
+  [pandas] is licensed under Mulan PSL v2.
+  You can use this software according to the terms and conditions of the Mulan PSL v2.
+  You may obtain a copy of Mulan PSL v2 at:
+           http://license.coscl.org.cn/MulanPSL2
+  THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND, EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT, MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+  See the Mulan PSL v2 for more details.
+*/
+
+package dataframe
+
+import (
+	"gitee.com/jn-qq/pandas/series"
+)
+
+// 消息编号，消息语言由 series.SetLanguage 设置
+const (
+	MsgColumnNotFound       = "ColumnNotFound"
+	MsgEmptyData            = "EmptyData"
+	MsgColumnNotFoundDetail = "ColumnNotFoundDetail"
+	MsgCellError            = "CellError"
+	MsgNamesNil             = "NamesNil"
+	MsgPrintTitle           = "PrintTitle"
+	MsgPrintEmpty           = "PrintEmpty"
+	MsgPrintIndex           = "PrintIndex"
+	MsgPrintTypes           = "PrintTypes"
+	MsgUnknownEncoding      = "UnknownEncoding"
+	MsgCodecNoWriter        = "CodecNoWriter"
+	MsgUnknownOrient        = "UnknownOrient"
+	MsgNDJSONLine           = "NDJSONLine"
+	MsgUnknownCodec         = "UnknownCodec"
+	MsgJSONSyntax           = "JSONSyntax"
+	MsgJSONUnexpected       = "JSONUnexpected"
+)
+
+func init() {
+	series.RegisterMessages(series.Zh, map[string]string{
+		MsgColumnNotFound:       "列不存在",
+		MsgEmptyData:            "输入数据不能为空",
+		MsgColumnNotFoundDetail: "列 %s 不存在",
+		MsgCellError:            "%s 第 %d 行第 %d 列（%s）%q 不能转换为 %s",
+		MsgNamesNil:             "names 为空",
+		MsgPrintTitle:           "DataFrame Size：%d x %d",
+		MsgPrintEmpty:           "DataFrame Is Empty",
+		MsgPrintIndex:           "Index",
+		MsgPrintTypes:           "Types",
+		MsgUnknownEncoding:      "不支持的编码 %s",
+		MsgCodecNoWriter:        "压缩格式 %s 不支持写入",
+		MsgUnknownOrient:        "不支持的 JSON 格式 %s",
+		MsgNDJSONLine:           "第 %d 行",
+		MsgUnknownCodec:         "不支持的压缩格式 %s",
+		MsgJSONSyntax:           "JSON 格式错误：第 %d 行（偏移 %d）",
+		MsgJSONUnexpected:       "期望 %s，实际 %s",
+	})
+	series.RegisterMessages(series.En, map[string]string{
+		MsgColumnNotFound:       "column not found",
+		MsgEmptyData:            "input data is empty",
+		MsgColumnNotFoundDetail: "column %s not found",
+		MsgCellError:            "%s row %d col %d (%s): cannot convert %q to %s",
+		MsgNamesNil:             "names is nil",
+		MsgPrintTitle:           "DataFrame Size: %d x %d",
+		MsgPrintEmpty:           "DataFrame Is Empty",
+		MsgPrintIndex:           "Index",
+		MsgPrintTypes:           "Types",
+		MsgUnknownEncoding:      "unsupported encoding %s",
+		MsgCodecNoWriter:        "codec %s does not support writing",
+		MsgUnknownOrient:        "unsupported JSON orient %s",
+		MsgNDJSONLine:           "line %d",
+		MsgUnknownCodec:         "unsupported codec %s",
+		MsgJSONSyntax:           "JSON syntax error at line %d (offset %d)",
+		MsgJSONUnexpected:       "expected %s, got %s",
+	})
+}
+
+// 错误类型，可通过 errors.Is 判断；长度、类型、转换等错误与 series 包共用，
+// 如 series.ErrLengthMismatch、series.ErrTypeMismatch、series.ErrParse
+var (
+	// ErrColumnNotFound 列不存在，对应 *ColumnNotFoundError
+	ErrColumnNotFound = series.NewError(nil, MsgColumnNotFound)
+	// ErrEmptyData 输入数据为空
+	ErrEmptyData = series.NewError(nil, MsgEmptyData)
+)
+
+// ColumnNotFoundError 列不存在
+type ColumnNotFoundError struct {
+	Name string // 列名
+}
+
+func (e *ColumnNotFoundError) Error() string {
+	return e.Localize(series.Language())
+}
+
+func (e *ColumnNotFoundError) Localize(lang series.Lang) string {
+	return series.Message(lang, MsgColumnNotFoundDetail, e.Name)
+}
+
+func (e *ColumnNotFoundError) Is(target error) bool {
+	return target == ErrColumnNotFound
+}
+
+// JSONSyntaxError JSON 数据格式错误，如 NDJSON 的某行不是对象，可通过 errors.Is 判断为 series.ErrParse
+type JSONSyntaxError struct {
+	Line   int    // 出错位置所在行，从 1 开始
+	Offset int64  // 发现错误前已读取的字节数，同 json.SyntaxError
+	Want   string // 期望的内容，如 {
+	Got    string // 实际读到的内容，Want 为空时为 encoding/json 的错误描述
+}
+
+func (e *JSONSyntaxError) Error() string {
+	return e.Localize(series.Language())
+}
+
+func (e *JSONSyntaxError) Localize(lang series.Lang) string {
+	detail := e.Got
+	if e.Want != "" {
+		detail = series.Message(lang, MsgJSONUnexpected, e.Want, e.Got)
+	}
+	return series.Message(lang, MsgJSONSyntax, e.Line, e.Offset) + ": " + detail
+}
+
+func (e *JSONSyntaxError) Is(target error) bool {
+	return target == series.ErrParse
+}
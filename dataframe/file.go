@@ -11,12 +11,18 @@
 package dataframe
 
 import (
+	"bufio"
+	"context"
 	"encoding/csv"
-	"fmt"
-	"gitee.com/jn-qq/go-tools/pandas/series"
+	"gitee.com/jn-qq/pandas/series"
 	"github.com/xuri/excelize/v2"
 	"io"
+	"io/fs"
+	"math"
 	"os"
+	"slices"
+	"strconv"
+	"strings"
 )
 
 const (
@@ -26,29 +32,187 @@ const (
 
 // Sheets 数据对象
 type Sheets struct {
-	SCol      int           // 开始列, 默认 1
-	SRow      int           // 开始行, 默认 1
-	ECol      int           // 结束列, 默认最后一列
-	ERow      int           // 结束行, 默认最后一行
-	Header    []string      // 表头，默认第一行
-	SheetName string        // 工作部名 XLSX 特有
-	ColsType  []series.Type // 列类型
+	SCol        int                    // 开始列, 默认 1
+	SRow        int                    // 开始行, 默认 1
+	ECol        int                    // 结束列, 默认最后一列
+	ERow        int                    // 结束行, 默认最后一行
+	Header      []string               // 表头，默认第一行
+	SheetName   string                 // 工作部名 XLSX 特有
+	ColsType    []series.Type          // 列类型，为 nil 时根据数据自动推断（规则见 series.InferType）
+	Types       map[string]series.Type // 按列名指定类型，优先于 ColsType 及自动推断
+	InferRows   int                    // 自动推断类型时的采样行数，默认 1000
+	Normalize   series.NormForm        // 读取时对表头及单元格文本的规范化方式，默认不处理
+	Parser      *series.NumberParser   // 数值解析器，默认 series.DefaultNumberParser
+	Errors      *CellErrors            // 不为 nil 时收集转换失败的单元格
+	Strict      bool                   // 严格模式，存在转换失败的单元格时返回 CellErrors 错误
+	NAValues    []string               // 额外视为空值的字符串，如 "-"、"N/A"、"无"
+	ColNAValues map[string][]string    // 按列名指定额外的空值字符串，与 NAValues 同时生效
+	KeepEmpty   bool                   // 空字符串作为有效值保留，仅对 String 类型列生效
+	Progress    func(rows int)         // 读取进度回调，参数为已读取的数据行数，每 series.ProgressStep 行及结束时回调
+	Dialect     CSVDialect             // CSV 格式，CSV 特有
+}
+
+// CSVDialect CSV 格式，零值为逗号分隔、带 UTF-8 BOM 及 LF 换行的标准格式。读取时总是去除开头的 UTF-8 BOM
+type CSVDialect struct {
+	Comma            rune     // 分隔符，默认 ','，如 '\t'、';'、'|'
+	Comment          rune     // 注释符，以其开头的行忽略，默认不处理
+	LazyQuotes       bool     // 读取时允许字段中出现不规范的引号
+	TrimLeadingSpace bool     // 读取时去除字段开头的空白
+	SkipBlankLines   bool     // 读取时跳过所有字段均为空白的行，空行总是跳过
+	NoBOM            bool     // 写入时不添加 UTF-8 BOM
+	UseCRLF          bool     // 写入时使用 \r\n 换行
+	FloatFormat      byte     // 写入浮点数的格式，同 strconv.FormatFloat，如 'f'、'e'、'g'，为 0 时使用最短表示
+	FloatPrecision   int      // 写入浮点数的精度，FloatFormat 不为 0 时生效，-1 表示最短表示
+	Encoding         Encoding // 文件编码，默认 UTF-8，UTF-8 BOM 仅在 UTF-8 编码时写入
+}
+
+// 创建按格式读取的 csv.Reader，转换编码并去除开头的 UTF-8 BOM
+func (d CSVDialect) newReader(r io.Reader) (*csv.Reader, error) {
+	r, err := d.Encoding.newReader(r)
+	if err != nil {
+		return nil, err
+	}
+	br := bufio.NewReader(r)
+	if bom, err := br.Peek(3); err == nil && string(bom) == "\xEF\xBB\xBF" {
+		_, _ = br.Discard(3)
+	}
+	reader := csv.NewReader(br)
+	if d.Comma != 0 {
+		reader.Comma = d.Comma
+	}
+	reader.Comment = d.Comment
+	reader.LazyQuotes = d.LazyQuotes
+	reader.TrimLeadingSpace = d.TrimLeadingSpace
+	return reader, nil
+}
+
+// 创建按格式写入的 csv.Writer
+func (d CSVDialect) newWriter(w io.Writer) *csv.Writer {
+	writer := csv.NewWriter(w)
+	if d.Comma != 0 {
+		writer.Comma = d.Comma
+	}
+	writer.UseCRLF = d.UseCRLF
+	return writer
+}
+
+// 判断是否为空白行
+func blankLine(record []string) bool {
+	for _, field := range record {
+		if strings.TrimSpace(field) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// 列 name 的加载配置
+func (sheet Sheets) loadOptions(name string) series.LoadOptions {
+	return series.LoadOptions{
+		Parser:    sheet.Parser,
+		NAValues:  append(slices.Clone(sheet.NAValues), sheet.ColNAValues[name]...),
+		KeepEmpty: sheet.KeepEmpty,
+	}
+}
+
+// CellError 单元格数据转换失败信息
+type CellError struct {
+	Sheet  string      // 工作表名，CSV 为文件路径
+	Row    int         // 行号，从 1 开始
+	Col    int         // 列号，从 1 开始
+	Column string      // 列名
+	Value  string      // 原始值
+	Type   series.Type // 目标类型
+}
+
+func (e *CellError) Error() string {
+	return e.Localize(series.Language())
+}
+
+func (e *CellError) Localize(lang series.Lang) string {
+	return series.Message(lang, MsgCellError, e.Sheet, e.Row, e.Col, e.Column, e.Value, e.Type)
+}
+
+func (e *CellError) Is(target error) bool {
+	return target == series.ErrParse
+}
+
+// CellErrors 单元格数据转换失败信息集合
+type CellErrors []*CellError
+
+func (e CellErrors) Error() string {
+	return e.Localize(series.Language())
+}
+
+func (e CellErrors) Localize(lang series.Lang) string {
+	msgs := make([]string, 0, len(e))
+	for _, err := range e {
+		msgs = append(msgs, err.Localize(lang))
+	}
+	return strings.Join(msgs, "\n")
+}
+
+// Unwrap 返回全部单元格错误，使 errors.Is(err, series.ErrParse) 及 errors.As(err, **CellError) 可用
+func (e CellErrors) Unwrap() []error {
+	errs := make([]error, 0, len(e))
+	for _, err := range e {
+		errs = append(errs, err)
+	}
+	return errs
 }
 
 // ReadXLSX 从XLSX中读取表格
 func ReadXLSX(filePath string, sheets ...Sheets) (map[string]*DataFrame, error) {
+	return ReadXLSXContext(context.Background(), filePath, sheets...)
+}
+
+// ReadXLSXContext 从XLSX中读取表格，ctx 取消时停止读取并返回 ctx.Err()，读取进度通过 Sheets.Progress 回调
+func ReadXLSXContext(ctx context.Context, filePath string, sheets ...Sheets) (map[string]*DataFrame, error) {
 	// 读取文档
 	f, err := excelize.OpenFile(filePath)
 	if err != nil {
 		return nil, err
 	}
+	return readXLSX(ctx, f, sheets)
+}
+
+// ReadXLSXFrom 从 r 中读取XLSX表格，如 HTTP 请求体
+func ReadXLSXFrom(r io.Reader, sheets ...Sheets) (map[string]*DataFrame, error) {
+	return ReadXLSXFromContext(context.Background(), r, sheets...)
+}
+
+// ReadXLSXFromContext 同 ReadXLSXFrom，ctx 取消时停止读取并返回 ctx.Err()
+func ReadXLSXFromContext(ctx context.Context, r io.Reader, sheets ...Sheets) (map[string]*DataFrame, error) {
+	f, err := excelize.OpenReader(r)
+	if err != nil {
+		return nil, err
+	}
+	return readXLSX(ctx, f, sheets)
+}
+
+// ReadXLSXFS 从文件系统 fsys 中读取XLSX表格，如 embed.FS
+func ReadXLSXFS(fsys fs.FS, name string, sheets ...Sheets) (frames map[string]*DataFrame, err error) {
+	file, err := fsys.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if closeErr := file.Close(); closeErr != nil && err == nil {
+			frames, err = nil, closeErr
+		}
+	}()
+	return ReadXLSXFrom(file, sheets...)
+}
+
+// 读取工作簿中的各工作表，结束后关闭工作簿
+func readXLSX(ctx context.Context, f *excelize.File, sheets []Sheets) (frames map[string]*DataFrame, err error) {
 	defer func() {
-		if err := f.Close(); err != nil {
-			return
+		if closeErr := f.Close(); closeErr != nil && err == nil {
+			frames, err = nil, closeErr
 		}
 	}()
 
-	frames := make(map[string]*DataFrame)
+	frames = make(map[string]*DataFrame)
 	// 遍历sheet
 	for _, sheet := range sheets {
 		// 初始化开始行列
@@ -62,12 +226,20 @@ func ReadXLSX(filePath string, sheets ...Sheets) (map[string]*DataFrame, error)
 		if err != nil {
 			return nil, err
 		}
-		// 表头，数据
+		report := newProgress(sheet.Progress)
+		// 表头，数据，数据行号
 		header := sheet.Header
 		var sheetData [][]string
+		var rowNums []int
 		// 遍历行
 		row := 1
 		for rows.Next() {
+			if (row-1)%series.ProgressStep == 0 {
+				if err := ctx.Err(); err != nil {
+					_ = rows.Close()
+					return nil, err
+				}
+			}
 			if row < sheet.SRow || (sheet.ERow != 0 && row > sheet.ERow) {
 				row += 1
 				continue
@@ -77,6 +249,7 @@ func ReadXLSX(filePath string, sheets ...Sheets) (map[string]*DataFrame, error)
 			// 行数据
 			columns, err := rows.Columns()
 			if err != nil {
+				_ = rows.Close()
 				return nil, err
 			}
 
@@ -86,14 +259,20 @@ func ReadXLSX(filePath string, sheets ...Sheets) (map[string]*DataFrame, error)
 
 			if header == nil {
 				// 表头
-				header = columns[sheet.SCol-1 : sheet.ECol]
+				header = normalizeRow(sliceRow(columns, sheet), sheet.Normalize)
 			} else {
 				// 表数据
-				sheetData = append(sheetData, columns[sheet.SCol-1:sheet.ECol])
+				sheetData = append(sheetData, normalizeRow(sliceRow(columns, sheet), sheet.Normalize))
+				rowNums = append(rowNums, row-1)
+				report.add(1)
 			}
 		}
+		if err := rows.Close(); err != nil {
+			return nil, err
+		}
+		report.done()
 
-		record, err := LoadRecord(sheetData, header, sheet.ColsType)
+		record, err := loadRecord(sheetData, header, sheet.ColsType, sheet, rowNums)
 		if err != nil {
 			return nil, err
 		}
@@ -102,92 +281,261 @@ func ReadXLSX(filePath string, sheets ...Sheets) (map[string]*DataFrame, error)
 	return frames, nil
 }
 
-// ReadCSV 从CSV中读取表格
+// ReadCSV 从CSV中读取表格，按扩展名或文件头识别并解压已注册的压缩格式，如 .csv.gz，见 RegisterCodec
 func ReadCSV(filePath string, sheet Sheets) (*DataFrame, error) {
-	// 初始化开始行列
-	if sheet.SCol == 0 {
-		sheet.SCol = 1
-	}
-	if sheet.SRow == 0 {
-		sheet.SRow = 1
-	}
+	return ReadCSVContext(context.Background(), filePath, sheet)
+}
+
+// ReadCSVContext 从CSV中读取表格，ctx 取消时停止读取并返回 ctx.Err()，读取进度通过 Sheets.Progress 回调
+func ReadCSVContext(ctx context.Context, filePath string, sheet Sheets) (df *DataFrame, err error) {
 	//打开文件(只读模式)，创建io.read接口实例
 	opencast, err := os.Open(filePath)
 	if err != nil {
 		return nil, err
 	}
 	defer func() {
-		if err := opencast.Close(); err != nil {
-			panic(err)
+		if closeErr := opencast.Close(); closeErr != nil && err == nil {
+			df, err = nil, closeErr
+		}
+	}()
+	if sheet.SheetName == "" {
+		sheet.SheetName = filePath
+	}
+	return readCSV(ctx, opencast, sheet, filePath)
+}
+
+// ReadCSVFS 从文件系统 fsys 中读取CSV表格，如 embed.FS
+func ReadCSVFS(fsys fs.FS, name string, sheet Sheets) (df *DataFrame, err error) {
+	file, err := fsys.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if closeErr := file.Close(); closeErr != nil && err == nil {
+			df, err = nil, closeErr
 		}
 	}()
+	if sheet.SheetName == "" {
+		sheet.SheetName = name
+	}
+	return readCSV(context.Background(), file, sheet, name)
+}
+
+// ReadCSVFrom 从 r 中读取CSV表格，如 HTTP 请求体，按文件头识别并解压已注册的压缩格式
+func ReadCSVFrom(r io.Reader, sheet Sheets) (*DataFrame, error) {
+	return ReadCSVFromContext(context.Background(), r, sheet)
+}
+
+// ReadCSVFromContext 同 ReadCSVFrom，ctx 取消时停止读取并返回 ctx.Err()
+func ReadCSVFromContext(ctx context.Context, r io.Reader, sheet Sheets) (*DataFrame, error) {
+	return readCSV(ctx, r, sheet, "")
+}
+
+// 从 r 中读取CSV表格，按文件名 name 的扩展名或文件头识别压缩格式
+func readCSV(ctx context.Context, r io.Reader, sheet Sheets, name string) (df *DataFrame, err error) {
 	// 创建csv对象
-	reader := csv.NewReader(opencast)
-	header := sheet.Header
+	reader := newCSVReader(r, sheet, name)
+	defer func() {
+		if closeErr := reader.close(); closeErr != nil && err == nil {
+			df, err = nil, closeErr
+		}
+	}()
 	var sheetData [][]string
-	row := 1
+	var rowNums []int
+	report := newProgress(sheet.Progress)
 	for {
-		record, err := reader.Read()
+		if len(sheetData)%series.ProgressStep == 0 {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+		}
+		record, row, err := reader.next()
 		if err == io.EOF {
 			break
 		}
 		if err != nil {
 			return nil, err
 		}
-		if row < sheet.SRow || (sheet.ERow != 0 && row > sheet.ERow) {
-			row += 1
+		sheetData = append(sheetData, record)
+		rowNums = append(rowNums, row)
+		report.add(1)
+	}
+	report.done()
+	record, err := loadRecord(sheetData, reader.header, reader.sheet.ColsType, reader.sheet, rowNums)
+	if err != nil {
+		return nil, err
+	}
+	return record, nil
+}
+
+// CSV 数据行读取器，跳过 SRow 之前的行，读取至 ERow，截取 SCol 至 ECol 列并规范化，未指定表头时第一行作为表头
+type csvReader struct {
+	reader *csv.Reader
+	sheet  Sheets
+	header []string
+	row    int       // 已读取的文件行数
+	err    error     // 创建读取器的错误，由 next 返回
+	closer io.Closer // 解压读取器
+}
+
+// 创建CSV数据行读取器，按文件名 name 的扩展名或文件头识别压缩格式，使用完毕后需调用 close
+func newCSVReader(r io.Reader, sheet Sheets, name string) *csvReader {
+	// 初始化开始行列
+	if sheet.SCol == 0 {
+		sheet.SCol = 1
+	}
+	if sheet.SRow == 0 {
+		sheet.SRow = 1
+	}
+	rc, err := decompress(r, name)
+	if err != nil {
+		return &csvReader{sheet: sheet, err: err}
+	}
+	reader, err := sheet.Dialect.newReader(rc)
+	return &csvReader{reader: reader, sheet: sheet, header: sheet.Header, err: err, closer: rc}
+}
+
+// 关闭解压读取器
+func (r *csvReader) close() error {
+	if r.closer == nil {
+		return nil
+	}
+	return r.closer.Close()
+}
+
+// 返回下一数据行及其在文件中的行号，读取完毕时返回 io.EOF
+func (r *csvReader) next() ([]string, int, error) {
+	if r.err != nil {
+		return nil, 0, r.err
+	}
+	for {
+		if r.sheet.ERow != 0 && r.row >= r.sheet.ERow {
+			return nil, 0, io.EOF
+		}
+		record, err := r.reader.Read()
+		if err != nil {
+			return nil, 0, err
+		}
+		r.row++
+		if r.row < r.sheet.SRow || (r.sheet.Dialect.SkipBlankLines && blankLine(record)) {
 			continue
-		} else {
-			row += 1
 		}
-
-		if sheet.ECol == 0 {
-			sheet.ECol = len(record)
+		if r.sheet.ECol == 0 {
+			r.sheet.ECol = len(record)
 		}
-
-		if header == nil {
-			header = record[sheet.SCol-1 : sheet.ECol]
-		} else {
-			sheetData = append(sheetData, record[sheet.SCol-1:sheet.ECol])
+		values := normalizeRow(sliceRow(record, r.sheet), r.sheet.Normalize)
+		if r.header == nil {
+			r.header = values
+			continue
 		}
+		return values, r.row, nil
 	}
-	record, err := LoadRecord(sheetData, header, sheet.ColsType)
-	if err != nil {
-		return nil, err
+}
+
+// 截取 SCol 至 ECol 列，行数据不足时以空字符串补齐
+func sliceRow(row []string, sheet Sheets) []string {
+	if sheet.ECol < sheet.SCol {
+		return []string{}
 	}
-	return record, nil
+	if len(row) < sheet.ECol {
+		row = append(row, make([]string, sheet.ECol-len(row))...)
+	}
+	return row[sheet.SCol-1 : sheet.ECol]
 }
 
-func (df *DataFrame) WriteToCSV(p string) error {
+// 按 form 规范化一行数据
+func normalizeRow(row []string, form series.NormForm) []string {
+	if form == 0 {
+		return row
+	}
+	for i, value := range row {
+		row[i] = series.Normalize(value, form)
+	}
+	return row
+}
+
+// WriteToCSV 将表格写入CSV文件，文件扩展名为已注册的压缩格式时压缩写入，如 .csv.gz
+//
+//	dialect: CSV 格式，可选，默认逗号分隔、带 UTF-8 BOM
+func (df *DataFrame) WriteToCSV(p string, dialect ...CSVDialect) (err error) {
 	newFile, err := os.Create(p)
 	if err != nil {
 		return err
 	}
 	defer func() {
-		if err := newFile.Close(); err != nil {
-			return
+		if closeErr := newFile.Close(); closeErr != nil && err == nil {
+			err = closeErr
 		}
 	}()
-	// 写入UTF-8 BOM，防止中文乱码
-	if _, err := newFile.WriteString("\xEF\xBB\xBF"); err != nil {
+	// 按扩展名压缩，如 .csv.gz
+	w, err := compress(newFile, p)
+	if err != nil {
+		return err
+	}
+	if err = df.WriteCSVTo(w, dialect...); err != nil {
+		_ = w.Close()
+		return err
+	}
+	return w.Close()
+}
+
+// WriteCSVTo 将表格以CSV格式写入 w，如 HTTP 响应
+//
+//	dialect: CSV 格式，可选，默认逗号分隔、带 UTF-8 BOM
+func (df *DataFrame) WriteCSVTo(w io.Writer, dialect ...CSVDialect) error {
+	var d CSVDialect
+	if len(dialect) > 0 {
+		d = dialect[0]
+	}
+	enc, err := d.Encoding.newWriter(w)
+	if err != nil {
 		return err
 	}
-	// 写数据到csv文件
-	w := csv.NewWriter(newFile)
+	// 写入UTF-8 BOM，防止中文乱码
+	if !d.NoBOM && (d.Encoding == "" || d.Encoding == UTF8 || d.Encoding == AutoDetect) {
+		if _, err := io.WriteString(w, "\xEF\xBB\xBF"); err != nil {
+			return err
+		}
+	}
+	records := df.Records(true, true)
+	if d.FloatFormat != 0 {
+		for j, column := range df.columns {
+			if column.Type() != string(series.Float) {
+				continue
+			}
+			for i, f := range column.Float() {
+				if !math.IsNaN(f) {
+					records[i+1][j] = strconv.FormatFloat(f, d.FloatFormat, d.FloatPrecision, 64)
+				}
+			}
+		}
+	}
 	// WriteAll方法使用Write方法向w写入多条记录，并在最后调用Flush方法清空缓存。
-	if err := w.WriteAll(df.Records(true, true)); err != nil {
+	if err := d.newWriter(enc).WriteAll(records); err != nil {
 		return err
 	}
-	w.Flush()
+	return enc.Close()
+}
 
-	return nil
+func (df *DataFrame) WriteToXLSX(p, sheetName string) (err error) {
+	newFile, err := os.Create(p)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if closeErr := newFile.Close(); closeErr != nil && err == nil {
+			err = closeErr
+		}
+	}()
+	return df.WriteXLSXTo(newFile, sheetName)
 }
 
-func (df *DataFrame) WriteToXLSX(p, sheetName string) error {
+// WriteXLSXTo 将表格以XLSX格式写入 w，如 HTTP 响应
+func (df *DataFrame) WriteXLSXTo(w io.Writer, sheetName string) (err error) {
 	f := excelize.NewFile()
 	defer func() {
-		if err := f.Close(); err != nil {
-			return
+		if closeErr := f.Close(); closeErr != nil && err == nil {
+			err = closeErr
 		}
 	}()
 
@@ -218,7 +566,11 @@ func (df *DataFrame) WriteToXLSX(p, sheetName string) error {
 	// 按列写入
 	for i, column := range df.columns {
 		d1 := column.Any()
-		if err = f.SetSheetCol(sheetName, fmt.Sprintf("%s2", string(rune(65+i))), &d1); err != nil {
+		cell, err := excelize.CoordinatesToCellName(i+1, 2)
+		if err != nil {
+			return err
+		}
+		if err = f.SetSheetCol(sheetName, cell, &d1); err != nil {
 			return err
 		}
 	}
@@ -236,8 +588,8 @@ func (df *DataFrame) WriteToXLSX(p, sheetName string) error {
 
 	// 设置工作簿的默认工作表
 	f.SetActiveSheet(index)
-	// 根据指定路径保存文件
-	if err = f.SaveAs(p); err != nil {
+	// 写入 w
+	if _, err = f.WriteTo(w); err != nil {
 		return err
 	}
 
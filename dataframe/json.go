@@ -0,0 +1,451 @@
+/**
+  Copyright (c) [2024] [JiangNan]
+  [pandas] is licensed under Mulan PSL v2.
+  You can use this software according to the terms and conditions of the Mulan PSL v2.
+  You may obtain a copy of Mulan PSL v2 at:
+           http://license.coscl.org.cn/MulanPSL2
+  THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND, EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT, MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+  See the Mulan PSL v2 for more details.
+*/
+
+package dataframe
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"gitee.com/jn-qq/pandas/series"
+	"io"
+	"os"
+	"slices"
+	"strconv"
+)
+
+// JSONOrient JSON 数据格式
+type JSONOrient string
+
+const (
+	// OrientRecords 对象数组，如 [{"name":"Join","age":12},{"name":"Mary","age":15}]
+	OrientRecords JSONOrient = "records"
+	// OrientColumns 列名到数据数组的对象，如 {"name":["Join","Mary"],"age":[12,15]}
+	OrientColumns JSONOrient = "columns"
+	// OrientSplit 列名、列类型与数据分开存储，如 {"columns":["name","age"],"types":["string","int"],"data":[["Join",12],["Mary",15]]}
+	OrientSplit JSONOrient = "split"
+	// OrientValues 仅数据的二维数组，如 [["Join",12],["Mary",15]]
+	OrientValues JSONOrient = "values"
+)
+
+// JSONOptions JSON 读写配置
+type JSONOptions struct {
+	Orient   JSONOrient    // 数据格式，默认 OrientRecords
+	ColsType []series.Type // 读取时的列类型，为 nil 时使用 OrientSplit 中的 types 或根据数据推断
+	Names    []string      // OrientValues 读取时的列名，为 nil 时为 "0"、"1"…
+	Indent   string        // 写入时的缩进，为空时不换行
+}
+
+// split 格式
+type jsonSplit struct {
+	Columns []string      `json:"columns"`
+	Types   []series.Type `json:"types,omitempty"`
+	Data    [][]any       `json:"data"`
+}
+
+// ReadJSON 从JSON文件中读取表格，空值为 null，按扩展名或文件头识别并解压已注册的压缩格式，如 .json.gz
+// 数据格式错误时返回带行号及偏移的 *JSONSyntaxError
+func ReadJSON(filePath string, opts JSONOptions) (df *DataFrame, err error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if closeErr := file.Close(); closeErr != nil && err == nil {
+			df, err = nil, closeErr
+		}
+	}()
+	return readJSON(file, opts, filePath)
+}
+
+// ReadJSONFrom 从 r 中读取JSON表格，按文件头识别并解压已注册的压缩格式
+func ReadJSONFrom(r io.Reader, opts JSONOptions) (*DataFrame, error) {
+	return readJSON(r, opts, "")
+}
+
+// 从 r 中读取JSON表格，按文件名 name 的扩展名或文件头识别压缩格式
+func readJSON(r io.Reader, opts JSONOptions, name string) (df *DataFrame, err error) {
+	rc, err := decompress(r, name)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if closeErr := rc.Close(); closeErr != nil && err == nil {
+			df, err = nil, closeErr
+		}
+	}()
+	lines := &lineCounter{r: rc}
+	dec := json.NewDecoder(lines)
+	dec.UseNumber()
+
+	var names []string
+	var columns [][]any
+	types := opts.ColsType
+	switch opts.Orient {
+	case "", OrientRecords:
+		names, columns, err = decodeRecords(dec)
+	case OrientColumns:
+		names, columns, err = decodeColumns(dec)
+	case OrientSplit:
+		var split jsonSplit
+		if err = dec.Decode(&split); err != nil {
+			return nil, syntaxError(err, lines.line, 0)
+		}
+		names, columns = split.Columns, transpose(split.Data, len(split.Columns))
+		if types == nil {
+			types = split.Types
+		}
+	case OrientValues:
+		var rows [][]any
+		if err = dec.Decode(&rows); err != nil {
+			return nil, syntaxError(err, lines.line, 0)
+		}
+		width := len(opts.Names)
+		for _, row := range rows {
+			width = max(width, len(row))
+		}
+		names, columns = opts.Names, transpose(rows, width)
+		for i := len(names); i < width; i++ {
+			names = append(names, strconv.Itoa(i))
+		}
+	default:
+		return nil, series.NewError(series.ErrInvalidArgument, MsgUnknownOrient, string(opts.Orient))
+	}
+	if err != nil {
+		return nil, syntaxError(err, lines.line, 0)
+	}
+	return loadJSON(names, columns, types)
+}
+
+// 用列名、列数据及列类型创建表格，types 为 nil 时根据数据推断
+func loadJSON(names []string, columns [][]any, types []series.Type) (*DataFrame, error) {
+	if types != nil && len(types) != len(names) {
+		return nil, &series.LengthMismatchError{Want: len(names), Got: len(types)}
+	}
+	if len(columns) != len(names) {
+		return nil, &series.LengthMismatchError{Want: len(names), Got: len(columns)}
+	}
+	values := make([]any, 0, len(columns))
+	for i, column := range columns {
+		var t series.Type
+		if types != nil {
+			t = types[i]
+		}
+		ns, err := series.LoadJSON(column, t, names[i])
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, ns)
+	}
+	return New(values, names)
+}
+
+// 解码对象数组，各对象的键按首次出现的顺序作为列名，缺少的键置为空值
+func decodeRecords(dec *json.Decoder) ([]string, [][]any, error) {
+	if err := expectDelim(dec, '['); err != nil {
+		return nil, nil, err
+	}
+	table := newJSONTable(nil)
+	for dec.More() {
+		keys, values, err := decodeObject(dec)
+		if err != nil {
+			return nil, nil, err
+		}
+		table.addRow(keys, values)
+	}
+	return table.names, table.columns, expectDelim(dec, ']')
+}
+
+// 按行构建的 JSON 表格，新出现的键作为新列，缺少的键置为 nil
+type jsonTable struct {
+	names   []string
+	columns [][]any
+	index   map[string]int
+	rows    int
+}
+
+// 创建 JSON 表格，names 为预先指定的列
+func newJSONTable(names []string) *jsonTable {
+	t := &jsonTable{index: make(map[string]int)}
+	for _, name := range names {
+		t.addColumn(name)
+	}
+	return t
+}
+
+func (t *jsonTable) addColumn(name string) int {
+	j := len(t.names)
+	t.index[name] = j
+	t.names = append(t.names, name)
+	t.columns = append(t.columns, make([]any, t.rows, t.rows+1))
+	return j
+}
+
+// 添加一行，重复的键取第一个值
+func (t *jsonTable) addRow(keys []string, values []any) {
+	for i, key := range keys {
+		j, ok := t.index[key]
+		if !ok {
+			j = t.addColumn(key)
+		}
+		if len(t.columns[j]) == t.rows {
+			t.columns[j] = append(t.columns[j], values[i])
+		}
+	}
+	t.rows++
+	for j := range t.columns {
+		if len(t.columns[j]) < t.rows {
+			t.columns[j] = append(t.columns[j], nil)
+		}
+	}
+}
+
+// 解码列名到数据数组的对象
+func decodeColumns(dec *json.Decoder) ([]string, [][]any, error) {
+	keys, values, err := decodeObject(dec)
+	if err != nil {
+		return nil, nil, err
+	}
+	columns := make([][]any, 0, len(values))
+	for _, value := range values {
+		column, ok := value.([]any)
+		if !ok && value != nil {
+			return nil, nil, &series.TypeMismatchError{Want: "[]any", Got: fmt.Sprintf("%T", value)}
+		}
+		columns = append(columns, column)
+	}
+	return keys, columns, nil
+}
+
+// 按顺序解码对象的键值
+func decodeObject(dec *json.Decoder) ([]string, []any, error) {
+	if err := expectDelim(dec, '{'); err != nil {
+		return nil, nil, err
+	}
+	var keys []string
+	var values []any
+	for dec.More() {
+		token, err := dec.Token()
+		if err != nil {
+			return nil, nil, err
+		}
+		var value any
+		if err = dec.Decode(&value); err != nil {
+			return nil, nil, err
+		}
+		keys = append(keys, token.(string))
+		values = append(values, value)
+	}
+	return keys, values, expectDelim(dec, '}')
+}
+
+// 读取下一个分隔符并检查是否为 delim，不符时返回 *JSONSyntaxError，其行号由调用方通过 syntaxError 补全
+func expectDelim(dec *json.Decoder, delim json.Delim) error {
+	token, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if token != delim {
+		return &JSONSyntaxError{Offset: dec.InputOffset(), Want: delim.String(), Got: fmt.Sprint(token)}
+	}
+	return nil
+}
+
+// 将 encoding/json 的语法错误转换为 *JSONSyntaxError，偏移加上 base 并由 line 计算行号，其他错误原样返回
+func syntaxError(err error, line func(offset int64) int, base int64) error {
+	var e *JSONSyntaxError
+	if se := (*json.SyntaxError)(nil); errors.As(err, &se) {
+		e = &JSONSyntaxError{Offset: se.Offset, Got: se.Error()}
+	} else if !errors.As(err, &e) {
+		return err
+	}
+	offset := e.Offset + base
+	return &JSONSyntaxError{Line: line(offset), Offset: offset, Want: e.Want, Got: e.Got}
+}
+
+// 读取时记录各换行符的位置，用于由偏移计算行号
+type lineCounter struct {
+	r     io.Reader
+	read  int64
+	lines []int64 // 各换行符距数据开头的字节数
+}
+
+func (c *lineCounter) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	for i, b := range p[:n] {
+		if b == '\n' {
+			c.lines = append(c.lines, c.read+int64(i))
+		}
+	}
+	c.read += int64(n)
+	return n, err
+}
+
+// 返回偏移 offset 所在的行，从 1 开始
+func (c *lineCounter) line(offset int64) int {
+	n, _ := slices.BinarySearch(c.lines, offset)
+	return n + 1
+}
+
+// 将行数据转置为 width 列，行数据不足时以 nil 补齐
+func transpose(rows [][]any, width int) [][]any {
+	columns := make([][]any, width)
+	for j := range columns {
+		columns[j] = make([]any, len(rows))
+		for i, row := range rows {
+			if j < len(row) {
+				columns[j][i] = row[j]
+			}
+		}
+	}
+	return columns
+}
+
+// WriteJSON 将表格写入JSON文件，文件扩展名为已注册的压缩格式时压缩写入，如 .json.gz
+func (df *DataFrame) WriteJSON(p string, opts JSONOptions) (err error) {
+	newFile, err := os.Create(p)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if closeErr := newFile.Close(); closeErr != nil && err == nil {
+			err = closeErr
+		}
+	}()
+	w, err := compress(newFile, p)
+	if err != nil {
+		return err
+	}
+	if err = df.WriteJSONTo(w, opts); err != nil {
+		_ = w.Close()
+		return err
+	}
+	return w.Close()
+}
+
+// WriteJSONTo 将表格以JSON格式写入 w，空值及无穷大记为 null，浮点数总带有小数点，
+// 使 OrientRecords 等不含类型的格式读取时仍能还原列类型
+func (df *DataFrame) WriteJSONTo(w io.Writer, opts JSONOptions) error {
+	data, err := df.encodeJSON(opts.Orient)
+	if err != nil {
+		return err
+	}
+	if opts.Indent != "" {
+		var buf bytes.Buffer
+		if err = json.Indent(&buf, data, "", opts.Indent); err != nil {
+			return err
+		}
+		data = buf.Bytes()
+	}
+	_, err = w.Write(data)
+	return err
+}
+
+// 按格式编码表格
+func (df *DataFrame) encodeJSON(orient JSONOrient) ([]byte, error) {
+	names := make([]string, 0, df.cols)
+	columns := make([][]any, 0, df.cols)
+	for i := range df.columns {
+		names = append(names, df.columns[i].Name)
+		columns = append(columns, df.columns[i].JSONValues())
+	}
+	switch orient {
+	case "", OrientRecords:
+		var buf bytes.Buffer
+		buf.WriteByte('[')
+		for i, row := range df.jsonRows(columns) {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			if err := encodeObject(&buf, names, row); err != nil {
+				return nil, err
+			}
+		}
+		buf.WriteByte(']')
+		return buf.Bytes(), nil
+	case OrientColumns:
+		var buf bytes.Buffer
+		values := make([]any, 0, len(columns))
+		for _, column := range columns {
+			values = append(values, column)
+		}
+		if err := encodeObject(&buf, names, values); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	case OrientSplit:
+		return json.Marshal(jsonSplit{Columns: names, Types: df.colTypes(), Data: df.jsonRows(columns)})
+	case OrientValues:
+		return json.Marshal(df.jsonRows(columns))
+	default:
+		return nil, series.NewError(series.ErrInvalidArgument, MsgUnknownOrient, string(orient))
+	}
+}
+
+// 按键的顺序编码对象
+func encodeObject(buf *bytes.Buffer, keys []string, values []any) error {
+	buf.WriteByte('{')
+	for i, key := range keys {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		k, err := json.Marshal(key)
+		if err != nil {
+			return err
+		}
+		v, err := json.Marshal(values[i])
+		if err != nil {
+			return err
+		}
+		buf.Write(k)
+		buf.WriteByte(':')
+		buf.Write(v)
+	}
+	buf.WriteByte('}')
+	return nil
+}
+
+// 将各列的 JSON 值转置为行
+func (df *DataFrame) jsonRows(columns [][]any) [][]any {
+	rows := make([][]any, 0, df.rows)
+	for i := 0; i < df.rows; i++ {
+		row := make([]any, 0, df.cols)
+		for _, column := range columns {
+			row = append(row, column[i])
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+// 返回各列类型
+func (df *DataFrame) colTypes() []series.Type {
+	types := make([]series.Type, 0, df.cols)
+	for i := range df.columns {
+		types = append(types, series.Type(df.columns[i].Type()))
+	}
+	return types
+}
+
+// MarshalJSON 实现 json.Marshaler，使用包含列类型的 OrientSplit 格式
+func (df *DataFrame) MarshalJSON() ([]byte, error) {
+	return df.encodeJSON(OrientSplit)
+}
+
+// UnmarshalJSON 实现 json.Unmarshaler，格式同 MarshalJSON
+func (df *DataFrame) UnmarshalJSON(data []byte) error {
+	ndf, err := ReadJSONFrom(bytes.NewReader(data), JSONOptions{Orient: OrientSplit})
+	if err != nil {
+		return err
+	}
+	*df = *ndf
+	return nil
+}
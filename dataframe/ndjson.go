@@ -0,0 +1,185 @@
+/**
+  Copyright (c) [2024] [JiangNan]
+  [pandas] is licensed under Mulan PSL v2.
+  You can use this software according to the terms and conditions of the Mulan PSL v2.
+  You may obtain a copy of Mulan PSL v2 at:
+           http://license.coscl.org.cn/MulanPSL2
+  THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND, EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT, MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+  See the Mulan PSL v2 for more details.
+*/
+
+package dataframe
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"gitee.com/jn-qq/pandas/series"
+	"io"
+	"os"
+)
+
+// ReadNDJSON 读取 NDJSON（JSON Lines）文件，每行一个对象，全部行读入内存后生成表格，不支持分块读取；
+// 按扩展名或文件头识别并解压已注册的压缩格式。
+// 某行不是合法的 JSON 对象时返回带行号及偏移的 *JSONSyntaxError。
+// 新出现的键作为新列，缺少的键及 null 置为空值（Bool 列没有空值，置为 false），嵌套对象展开为以 "." 连接的列名，如 {"user":{"id":1}} 为 user.id
+//
+//	opts.Names: 预先指定的列及顺序，其余列按首次出现的顺序追加
+//	opts.ColsType: 按列顺序指定的列类型，不足或为空的列根据数据推断
+func ReadNDJSON(filePath string, opts JSONOptions) (df *DataFrame, err error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if closeErr := file.Close(); closeErr != nil && err == nil {
+			df, err = nil, closeErr
+		}
+	}()
+	return readNDJSON(file, opts, filePath)
+}
+
+// ReadNDJSONFrom 从 r 中读取 NDJSON，同 ReadNDJSON
+func ReadNDJSONFrom(r io.Reader, opts JSONOptions) (*DataFrame, error) {
+	return readNDJSON(r, opts, "")
+}
+
+// 从 r 中读取 NDJSON，逐行解析后将各列的值保存在内存中，读完后生成表格；按文件名 name 的扩展名或文件头识别压缩格式
+func readNDJSON(r io.Reader, opts JSONOptions, name string) (df *DataFrame, err error) {
+	rc, err := decompress(r, name)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if closeErr := rc.Close(); closeErr != nil && err == nil {
+			df, err = nil, closeErr
+		}
+	}()
+	reader := bufio.NewReader(rc)
+	table := newJSONTable(opts.Names)
+	var offset int64
+	for line := 1; ; line++ {
+		b, readErr := reader.ReadBytes('\n')
+		if len(bytes.TrimSpace(b)) > 0 {
+			keys, values, err := flattenObject(b)
+			if err != nil {
+				err = syntaxError(err, func(int64) int { return line }, offset)
+				if _, ok := err.(*JSONSyntaxError); ok {
+					return nil, err
+				}
+				return nil, series.NewError(err, MsgNDJSONLine, line)
+			}
+			table.addRow(keys, values)
+		}
+		offset += int64(len(b))
+		if readErr == io.EOF {
+			break
+		}
+		if readErr != nil {
+			return nil, readErr
+		}
+	}
+	types := make([]series.Type, len(table.names))
+	copy(types, opts.ColsType)
+	return loadJSON(table.names, table.columns, types)
+}
+
+// 解码一行对象，嵌套对象展开为以 "." 连接的键
+func flattenObject(data []byte) ([]string, []any, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var keys []string
+	var values []any
+	if err := decodeFlat(dec, "", &keys, &values); err != nil {
+		return nil, nil, err
+	}
+	if token, err := dec.Token(); err == nil {
+		return nil, nil, &JSONSyntaxError{Offset: dec.InputOffset(), Want: "EOF", Got: fmt.Sprint(token)}
+	} else if err != io.EOF {
+		return nil, nil, err
+	}
+	return keys, values, nil
+}
+
+// 按顺序解码对象的键值，嵌套对象以 prefix 为前缀递归展开
+func decodeFlat(dec *json.Decoder, prefix string, keys *[]string, values *[]any) error {
+	if err := expectDelim(dec, '{'); err != nil {
+		return err
+	}
+	for dec.More() {
+		token, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		var raw json.RawMessage
+		if err = dec.Decode(&raw); err != nil {
+			return err
+		}
+		key := prefix + token.(string)
+		sub := json.NewDecoder(bytes.NewReader(raw))
+		sub.UseNumber()
+		if trimmed := bytes.TrimSpace(raw); len(trimmed) > 0 && trimmed[0] == '{' {
+			if err = decodeFlat(sub, key+".", keys, values); err != nil {
+				return err
+			}
+			continue
+		}
+		var value any
+		if err = sub.Decode(&value); err != nil {
+			return err
+		}
+		*keys = append(*keys, key)
+		*values = append(*values, value)
+	}
+	return expectDelim(dec, '}')
+}
+
+// WriteNDJSON 将表格逐行写入 NDJSON 文件，每行一个对象，文件扩展名为已注册的压缩格式时压缩写入
+func (df *DataFrame) WriteNDJSON(p string) (err error) {
+	newFile, err := os.Create(p)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if closeErr := newFile.Close(); closeErr != nil && err == nil {
+			err = closeErr
+		}
+	}()
+	w, err := compress(newFile, p)
+	if err != nil {
+		return err
+	}
+	if err = df.WriteNDJSONTo(w); err != nil {
+		_ = w.Close()
+		return err
+	}
+	return w.Close()
+}
+
+// WriteNDJSONTo 将表格逐行以 NDJSON 格式写入 w，空值记为 null，列名原样作为键
+func (df *DataFrame) WriteNDJSONTo(w io.Writer) error {
+	names := make([]string, 0, df.cols)
+	columns := make([][]any, 0, df.cols)
+	for i := range df.columns {
+		names = append(names, df.columns[i].Name)
+		columns = append(columns, df.columns[i].JSONValues())
+	}
+	writer := bufio.NewWriter(w)
+	var buf bytes.Buffer
+	row := make([]any, df.cols)
+	for i := 0; i < df.rows; i++ {
+		for j, column := range columns {
+			row[j] = column[i]
+		}
+		buf.Reset()
+		if err := encodeObject(&buf, names, row); err != nil {
+			return err
+		}
+		buf.WriteByte('\n')
+		if _, err := writer.Write(buf.Bytes()); err != nil {
+			return err
+		}
+	}
+	return writer.Flush()
+}
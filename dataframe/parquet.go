@@ -0,0 +1,388 @@
+/**
+  Copyright (c) [2024] [JiangNan]
+  [pandas] is licensed under Mulan PSL v2.
+  You can use this software according to the terms and conditions of the Mulan PSL v2.
+  You may obtain a copy of Mulan PSL v2 at:
+           http://license.coscl.org.cn/MulanPSL2
+  THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND, EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT, MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+  See the Mulan PSL v2 for more details.
+*/
+
+package dataframe
+
+import (
+	"gitee.com/jn-qq/pandas/internal/parquet"
+	"gitee.com/jn-qq/pandas/series"
+	"io"
+	"math"
+	"os"
+)
+
+// 写入时每个行组的默认行数
+const defaultRowGroupSize = 1 << 20
+
+// ParquetOptions Parquet 读写配置
+//
+//	读取时 INT32/INT64 为 series.Int，FLOAT/DOUBLE 为 series.Float，BOOLEAN 为 series.Bool，字符串及二进制为 series.String，
+//	有效位数不超过 15 位的 DECIMAL 为 series.Float，超过 15 位时为保留精确值的 series.String（如 "12345678901234567.89"），
+//	DATE 为 "2006-01-02" 格式、TIMESTAMP 及 INT96 为 RFC 3339 格式的 series.String，
+//	基本类型的 LIST 为 series.List(T)，结构体展开为以 "." 连接的列名，如 user.id；
+//	Map、结构体列表等不支持的列需通过 Columns 排除。定义级别标记的空值读取为 NaN，series.Bool 没有空值，读取为 false，字符串按原值读取，"NaN" 等不视为空值
+type ParquetOptions struct {
+	Columns      []string // 读取的列及顺序，为 nil 时读取全部列，只读取所选列的数据
+	Compression  string   // 写入的压缩格式：snappy（默认）、gzip、uncompressed，或通过 RegisterCodec 注册的 zstd、brotli 等
+	RowGroupSize int      // 写入时每个行组的行数，默认 1048576
+}
+
+// ReadParquet 从Parquet文件中读取表格，Snappy、Gzip 以外的压缩格式需通过 RegisterCodec 以对应名称注册，如 "zstd"。
+// 文件损坏时返回的错误可通过 errors.Is(err, series.ErrCorruptData) 判断，不支持的列或编码可通过 series.ErrUnsupportedOperation 判断
+func ReadParquet(filePath string, opts ParquetOptions) (df *DataFrame, err error) {
+	groups, err := ReadParquetRowGroups(filePath, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if closeErr := groups.Close(); closeErr != nil && err == nil {
+			df, err = nil, closeErr
+		}
+	}()
+	return groups.readAll()
+}
+
+// ReadParquetFrom 从 r 中读取Parquet表格，size 为数据大小
+func ReadParquetFrom(r io.ReaderAt, size int64, opts ParquetOptions) (*DataFrame, error) {
+	groups, err := ReadParquetRowGroupsFrom(r, size, opts)
+	if err != nil {
+		return nil, err
+	}
+	return groups.readAll()
+}
+
+// ParquetRowGroups Parquet 行组读取器，每次读取一个行组生成一个 DataFrame
+//
+//	for groups.Next() {
+//		df := groups.DataFrame()
+//	}
+//	if err := groups.Err(); err != nil {
+//	}
+type ParquetRowGroups struct {
+	file    io.Closer // ReadParquetRowGroups 打开的文件，由 Close 关闭
+	pf      *parquet.File
+	columns []*parquet.Column
+	types   []series.Type
+	next    int
+	df      *DataFrame
+	err     error
+}
+
+// ReadParquetRowGroups 按行组分块读取Parquet文件，使用完毕后需调用 Close
+func ReadParquetRowGroups(filePath string, opts ParquetOptions) (*ParquetRowGroups, error) {
+	f, err := os.Open(filePath)
+	if err != nil {
+		return nil, err
+	}
+	info, err := f.Stat()
+	if err != nil {
+		_ = f.Close()
+		return nil, err
+	}
+	groups, err := ReadParquetRowGroupsFrom(f, info.Size(), opts)
+	if err != nil {
+		_ = f.Close()
+		return nil, err
+	}
+	groups.file = f
+	return groups, nil
+}
+
+// ReadParquetRowGroupsFrom 从 r 中按行组分块读取Parquet表格，size 为数据大小
+func ReadParquetRowGroupsFrom(r io.ReaderAt, size int64, opts ParquetOptions) (*ParquetRowGroups, error) {
+	pf, err := parquet.Open(r, size)
+	if err != nil {
+		return nil, err
+	}
+	pf.Decompress = decompressParquet
+	columns, err := selectColumns(pf.Columns(), func(c *parquet.Column) string { return c.Name }, opts.Columns)
+	if err != nil {
+		return nil, err
+	}
+	groups := &ParquetRowGroups{pf: pf, columns: columns}
+	for _, c := range columns {
+		if c.Err != nil {
+			return nil, c.Err
+		}
+		t := kindType(c.Kind)
+		if c.List {
+			t = series.List(t)
+		}
+		groups.types = append(groups.types, t)
+	}
+	return groups, nil
+}
+
+// Next 读取下一个行组，没有更多数据或出错时返回 false
+func (g *ParquetRowGroups) Next() bool {
+	g.df = nil
+	if g.err != nil || g.next >= g.pf.NumRowGroups() {
+		return false
+	}
+	values := make([][]any, len(g.columns))
+	if g.err = g.read(g.next, values); g.err != nil {
+		return false
+	}
+	g.next++
+	g.df, g.err = g.load(values)
+	return g.err == nil
+}
+
+// DataFrame 返回当前行组
+func (g *ParquetRowGroups) DataFrame() *DataFrame {
+	return g.df
+}
+
+// Err 返回读取过程中的错误
+func (g *ParquetRowGroups) Err() error {
+	return g.err
+}
+
+// Close 关闭 ReadParquetRowGroups 打开的文件
+func (g *ParquetRowGroups) Close() error {
+	if g.file == nil {
+		return nil
+	}
+	return g.file.Close()
+}
+
+// 将第 i 个行组各列的值追加至 values
+func (g *ParquetRowGroups) read(i int, values [][]any) error {
+	for j, c := range g.columns {
+		column, err := g.pf.ReadColumn(i, c)
+		if err != nil {
+			return err
+		}
+		values[j] = append(values[j], column...)
+	}
+	return nil
+}
+
+// 读取剩余全部行组为一个表格
+func (g *ParquetRowGroups) readAll() (*DataFrame, error) {
+	values := make([][]any, len(g.columns))
+	for ; g.next < g.pf.NumRowGroups(); g.next++ {
+		if err := g.read(g.next, values); err != nil {
+			return nil, err
+		}
+	}
+	return g.load(values)
+}
+
+// 用各列的值创建表格
+func (g *ParquetRowGroups) load(values [][]any) (*DataFrame, error) {
+	names := make([]string, 0, len(g.columns))
+	for _, c := range g.columns {
+		names = append(names, c.Name)
+	}
+	return loadColumns(names, g.types, values)
+}
+
+// 按名称选取列，names 为 nil 时返回全部列，同名列取第一个
+func selectColumns[C any](columns []C, name func(C) string, names []string) ([]C, error) {
+	if names == nil {
+		return columns, nil
+	}
+	byName := make(map[string]C, len(columns))
+	for _, c := range columns {
+		if _, ok := byName[name(c)]; !ok {
+			byName[name(c)] = c
+		}
+	}
+	selected := make([]C, 0, len(names))
+	for _, n := range names {
+		c, ok := byName[n]
+		if !ok {
+			return nil, &ColumnNotFoundError{Name: n}
+		}
+		selected = append(selected, c)
+	}
+	return selected, nil
+}
+
+// 用各列的值创建表格，空值为 nil
+func loadColumns(names []string, types []series.Type, values [][]any) (*DataFrame, error) {
+	columns := make([]any, 0, len(names))
+	for j, name := range names {
+		ns, err := series.LoadValues(values[j], types[j], name)
+		if err != nil {
+			return nil, err
+		}
+		columns = append(columns, ns)
+	}
+	return New(columns, names)
+}
+
+func kindType(kind parquet.Kind) series.Type {
+	switch kind {
+	case parquet.KindInt:
+		return series.Int
+	case parquet.KindFloat:
+		return series.Float
+	case parquet.KindBool:
+		return series.Bool
+	default:
+		return series.String
+	}
+}
+
+// 通过已注册的同名压缩格式解压页数据
+func decompressParquet(codec parquet.Codec, data []byte, size int) ([]byte, error) {
+	return decompressBytes(codec.String(), data, size)
+}
+
+// 通过已注册的同名压缩格式压缩页数据
+func compressParquet(codec parquet.Codec, data []byte) ([]byte, error) {
+	return compressBytes(codec.String(), data)
+}
+
+// WriteParquet 将表格写入Parquet文件，各列均写为可空列，空值记为 null，series.Datetime 按 Records 的格式写为字符串
+func (df *DataFrame) WriteParquet(p string, opts ParquetOptions) (err error) {
+	newFile, err := os.Create(p)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if closeErr := newFile.Close(); closeErr != nil && err == nil {
+			err = closeErr
+		}
+	}()
+	return df.WriteParquetTo(newFile, opts)
+}
+
+// WriteParquetTo 将表格以Parquet格式写入 w，每 opts.RowGroupSize 行为一个行组
+func (df *DataFrame) WriteParquetTo(w io.Writer, opts ParquetOptions) error {
+	codec := parquet.Snappy
+	if opts.Compression != "" {
+		var ok bool
+		if codec, ok = parquet.CodecByName(opts.Compression); !ok {
+			return series.NewError(series.ErrInvalidArgument, MsgUnknownCodec, opts.Compression)
+		}
+	}
+	size := opts.RowGroupSize
+	if size <= 0 {
+		size = defaultRowGroupSize
+	}
+	fields := make([]parquet.Field, 0, df.cols)
+	columns := make([][]any, 0, df.cols)
+	for i := range df.columns {
+		field, values, err := parquetColumn(&df.columns[i])
+		if err != nil {
+			return err
+		}
+		fields = append(fields, field)
+		columns = append(columns, values)
+	}
+	pw, err := parquet.NewWriter(w, fields, codec, compressParquet)
+	if err != nil {
+		return err
+	}
+	for start := 0; start < df.rows; start += size {
+		end := min(start+size, df.rows)
+		group := make([][]any, 0, len(columns))
+		for _, column := range columns {
+			group = append(group, column[start:end])
+		}
+		if err = pw.WriteRowGroup(group); err != nil {
+			return err
+		}
+	}
+	return pw.Close()
+}
+
+// 返回数据列对应的 Parquet 列及各行的值，空值为 nil
+func parquetColumn(s *series.Series) (parquet.Field, []any, error) {
+	t := series.Type(s.Type())
+	field := parquet.Field{Name: s.Name, List: t.IsList()}
+	switch t.Elem() {
+	case series.Int:
+		field.Kind = parquet.KindInt
+	case series.Float:
+		field.Kind = parquet.KindFloat
+	case series.Bool:
+		field.Kind = parquet.KindBool
+	case series.String, series.Datetime:
+		field.Kind = parquet.KindString
+	default:
+		return field, nil, &series.UnsupportedOperationError{Op: "WriteParquet", Type: t}
+	}
+	return field, nullableValues(s), nil
+}
+
+// 返回数据列各行的值，空值为 nil，列表元素值转换为 []any
+func nullableValues(s *series.Series) []any {
+	if series.Type(s.Type()).Elem() == series.String {
+		// 字符串按原值写入，"NaN" 等不视为空值
+		return s.JSONValues()
+	}
+	if series.Type(s.Type()) == series.Datetime {
+		// 日期时间按 Records 的格式写为字符串
+		records := s.Records()
+		values := make([]any, len(records))
+		for i, record := range records {
+			values[i] = nullValue(record)
+		}
+		return values
+	}
+	values := s.Any()
+	list := series.Type(s.Type()).IsList()
+	for i, value := range values {
+		if list {
+			if value != nil {
+				values[i] = listItems(value)
+			}
+			continue
+		}
+		values[i] = nullValue(value)
+	}
+	return values
+}
+
+// 列表元素值转换为 []any，空值元素为 nil
+func listItems(value any) []any {
+	var items []any
+	switch x := value.(type) {
+	case []int:
+		items = make([]any, 0, len(x))
+		for _, v := range x {
+			items = append(items, nullValue(v))
+		}
+	case []float64:
+		items = make([]any, 0, len(x))
+		for _, v := range x {
+			items = append(items, nullValue(v))
+		}
+	case []bool:
+		items = make([]any, 0, len(x))
+		for _, v := range x {
+			items = append(items, v)
+		}
+	}
+	return items
+}
+
+// 空值转换为 nil
+func nullValue(value any) any {
+	switch x := value.(type) {
+	case int:
+		if x == math.MinInt {
+			return nil
+		}
+	case float64:
+		if math.IsNaN(x) {
+			return nil
+		}
+	case string:
+		if x == "NaN" {
+			return nil
+		}
+	}
+	return value
+}
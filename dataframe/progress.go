@@ -0,0 +1,55 @@
+/**
+  Copyright (c) [2024] [JiangNan]
+  [pandas] is licensed under Mulan PSL v2.
+  You can use this software according to the terms and conditions of the Mulan PSL v2.
+  You may obtain a copy of Mulan PSL v2 at:
+           http://license.coscl.org.cn/MulanPSL2
+  THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND, EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT, MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+  See the Mulan PSL v2 for more details.
+*/
+
+package dataframe
+
+import (
+	"gitee.com/jn-qq/pandas/series"
+	"sync"
+)
+
+// 进度报告，可在多个协程中调用，回调函数串行执行，每累计 series.ProgressStep 行回调一次
+type progress struct {
+	mu   sync.Mutex
+	f    func(rows int)
+	rows int
+	last int
+}
+
+func newProgress(f func(rows int)) *progress {
+	return &progress{f: f}
+}
+
+// 累计处理 n 行
+func (p *progress) add(n int) {
+	if p.f == nil {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.rows += n
+	if p.rows-p.last >= series.ProgressStep {
+		p.last = p.rows
+		p.f(p.rows)
+	}
+}
+
+// 结束时报告总行数
+func (p *progress) done() {
+	if p.f == nil {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.rows != p.last || p.rows == 0 {
+		p.last = p.rows
+		p.f(p.rows)
+	}
+}
@@ -0,0 +1,152 @@
+/**
+  Copyright (c) [2024] [JiangNan]
+  [pandas] is licensed under Mulan PSL v2.
+  You can use this software according to the terms and conditions of the Mulan PSL v2.
+  You may obtain a copy of Mulan PSL v2 at:
+           http://license.coscl.org.cn/MulanPSL2
+  THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND, EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT, MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+  See the Mulan PSL v2 for more details.
+*/
+
+package dataframe
+
+import (
+	"bufio"
+	"encoding/binary"
+	"gitee.com/jn-qq/pandas/series"
+	"hash/crc32"
+	"io"
+	"math"
+)
+
+// 快照文件头标识
+const snapshotMagic = "PDSNAP"
+
+// 快照格式版本，格式不兼容时递增，Load 拒绝读取高于该版本的快照
+const snapshotVersion uint16 = 1
+
+// Save 将表格保存为二进制快照，可由 Load 读取，读取速度远快于重新解析 CSV、Excel 等文件。
+//
+//	格式：文件头 "PDSNAP"、2 字节版本号、列数、行数，之后为各列的长度及 series.Series.MarshalBinary 数据，
+//	末尾为之前全部数据的 CRC-32 校验值。列名、类型、列顺序、空值及索引原样保存，浮点数按位保存，NaN 的负载位不变
+func (df *DataFrame) Save(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	crc := crc32.NewIEEE()
+	mw := io.MultiWriter(bw, crc)
+	head := binary.LittleEndian.AppendUint16([]byte(snapshotMagic), snapshotVersion)
+	head = binary.AppendUvarint(head, uint64(df.cols))
+	head = binary.AppendUvarint(head, uint64(df.rows))
+	if _, err := mw.Write(head); err != nil {
+		return err
+	}
+	for i := range df.columns {
+		data, err := df.columns[i].MarshalBinary()
+		if err != nil {
+			return err
+		}
+		if _, err = mw.Write(binary.AppendUvarint(nil, uint64(len(data)))); err != nil {
+			return err
+		}
+		if _, err = mw.Write(data); err != nil {
+			return err
+		}
+	}
+	if _, err := bw.Write(crc.Sum(nil)); err != nil {
+		return err
+	}
+	return bw.Flush()
+}
+
+// Load 读取 Save 保存的二进制快照，数据损坏或版本高于当前支持的版本时返回错误
+func Load(r io.Reader) (*DataFrame, error) {
+	crc := crc32.NewIEEE()
+	br := &snapshotReader{r: bufio.NewReader(r), crc: crc}
+	head := make([]byte, len(snapshotMagic)+2)
+	if err := br.read(head); err != nil {
+		return nil, err
+	}
+	if string(head[:len(snapshotMagic)]) != snapshotMagic {
+		return nil, series.ErrCorruptData
+	}
+	switch version := binary.LittleEndian.Uint16(head[len(snapshotMagic):]); {
+	case version == 0:
+		return nil, series.ErrCorruptData
+	case version > snapshotVersion:
+		return nil, series.NewError(series.ErrInvalidArgument, series.MsgUnsupportedVersion, version)
+	}
+	cols, err := br.uvarint()
+	if err != nil {
+		return nil, err
+	}
+	rows, err := br.uvarint()
+	if err != nil {
+		return nil, err
+	}
+	if rows > math.MaxInt {
+		return nil, series.ErrCorruptData
+	}
+	df := &DataFrame{}
+	for i := uint64(0); i < cols; i++ {
+		size, err := br.uvarint()
+		if err != nil {
+			return nil, err
+		}
+		data, err := io.ReadAll(io.LimitReader(br, int64(min(size, 1<<62))))
+		if err != nil {
+			return nil, err
+		}
+		if uint64(len(data)) != size {
+			return nil, series.ErrCorruptData
+		}
+		var s series.Series
+		if err = s.UnmarshalBinary(data); err != nil {
+			return nil, err
+		}
+		if uint64(s.Len()) != rows {
+			return nil, series.ErrCorruptData
+		}
+		df.columns = append(df.columns, s)
+	}
+	sum := crc.Sum(nil)
+	check := make([]byte, len(sum))
+	if _, err = io.ReadFull(br.r, check); err != nil || string(check) != string(sum) {
+		return nil, series.ErrCorruptData
+	}
+	df.cols, df.rows = len(df.columns), int(rows)
+	return df, nil
+}
+
+// 快照读取器，读取的数据同时写入校验
+type snapshotReader struct {
+	r   *bufio.Reader
+	crc io.Writer
+}
+
+func (sr *snapshotReader) Read(p []byte) (int, error) {
+	n, err := sr.r.Read(p)
+	_, _ = sr.crc.Write(p[:n])
+	return n, err
+}
+
+func (sr *snapshotReader) ReadByte() (byte, error) {
+	b, err := sr.r.ReadByte()
+	if err == nil {
+		_, _ = sr.crc.Write([]byte{b})
+	}
+	return b, err
+}
+
+func (sr *snapshotReader) read(p []byte) error {
+	if _, err := io.ReadFull(sr, p); err != nil {
+		return series.ErrCorruptData
+	}
+	return nil
+}
+
+func (sr *snapshotReader) uvarint() (uint64, error) {
+	v, err := binary.ReadUvarint(sr)
+	if err != nil {
+		return 0, series.ErrCorruptData
+	}
+	return v, nil
+}
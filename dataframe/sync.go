@@ -0,0 +1,194 @@
+/**
+  Copyright (c) [2024] [JiangNan]
+  [pandas] is licensed under Mulan PSL v2.
+  You can use this software according to the terms and conditions of the Mulan PSL v2.
+  You may obtain a copy of Mulan PSL v2 at:
+           http://license.coscl.org.cn/MulanPSL2
+  THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND, EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT, MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+  See the Mulan PSL v2 for more details.
+*/
+
+package dataframe
+
+import (
+	"gitee.com/jn-qq/pandas/series"
+	"sync"
+)
+
+// SyncDataFrame 并发安全的 DataFrame，读操作共享读锁并发执行，写操作独占写锁。
+// 返回的表、列及元素均与内部数据相互独立，修改不会影响 SyncDataFrame
+type SyncDataFrame struct {
+	mu      sync.RWMutex
+	df      *DataFrame
+	shareMu sync.Mutex // 持有读锁时保护 dirty 及标记共享
+	dirty   bool       // 存在写入后尚未标记共享的列
+}
+
+// NewSync 创建并发安全的 DataFrame，df 交由 SyncDataFrame 管理，之后不应再直接使用
+func NewSync(df *DataFrame) *SyncDataFrame {
+	if df == nil {
+		df, _ = New(nil, nil)
+	}
+	return &SyncDataFrame{df: df, dirty: true}
+}
+
+// 获取读锁。存在写入时由首个读操作在 shareMu 下将各列标记为共享，
+// 其余读操作等待标记完成后才访问数据，之后读锁下的 Copy、SubSet 等操作均不写入内部数据
+func (s *SyncDataFrame) rlock() {
+	s.mu.RLock()
+	s.shareMu.Lock()
+	if s.dirty {
+		s.df.share()
+		s.dirty = false
+	}
+	s.shareMu.Unlock()
+}
+
+// Read 以当前数据的副本执行 f，副本与内部数据共享元素直至其中一方写入，
+// f 中可调用 Rows、Cell 等任意方法，修改副本不会影响 SyncDataFrame
+func (s *SyncDataFrame) Read(f func(df *DataFrame)) {
+	f(s.Snapshot())
+}
+
+// Write 在写锁下执行 f
+func (s *SyncDataFrame) Write(f func(df *DataFrame) error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.dirty = true
+	return f(s.df)
+}
+
+// Snapshot 返回当前数据的副本，副本与内部数据共享元素直至其中一方写入
+func (s *SyncDataFrame) Snapshot() *DataFrame {
+	s.rlock()
+	defer s.mu.RUnlock()
+	return s.df.Copy()
+}
+
+func (s *SyncDataFrame) Records(isRow bool, hasColName bool) [][]string {
+	s.rlock()
+	defer s.mu.RUnlock()
+	return s.df.Records(isRow, hasColName)
+}
+
+func (s *SyncDataFrame) Names() []string {
+	s.rlock()
+	defer s.mu.RUnlock()
+	return s.df.Names()
+}
+
+func (s *SyncDataFrame) Types() []string {
+	s.rlock()
+	defer s.mu.RUnlock()
+	return s.df.Types()
+}
+
+func (s *SyncDataFrame) String() string {
+	return s.Print(false)
+}
+
+func (s *SyncDataFrame) Print(isComplete bool) string {
+	s.rlock()
+	defer s.mu.RUnlock()
+	return s.df.Print(isComplete)
+}
+
+func (s *SyncDataFrame) Size() (cols, rows int) {
+	s.rlock()
+	defer s.mu.RUnlock()
+	return s.df.Size()
+}
+
+func (s *SyncDataFrame) NCols() int {
+	s.rlock()
+	defer s.mu.RUnlock()
+	return s.df.NCols()
+}
+
+func (s *SyncDataFrame) NRows() int {
+	s.rlock()
+	defer s.mu.RUnlock()
+	return s.df.NRows()
+}
+
+// Columns 返回列的副本
+func (s *SyncDataFrame) Columns(name string) (series.Series, error) {
+	s.rlock()
+	defer s.mu.RUnlock()
+	return s.df.Columns(name)
+}
+
+// Cell 返回指定单元格元素的副本
+func (s *SyncDataFrame) Cell(r int, name string) (series.Element, error) {
+	column, err := s.Columns(name)
+	if err != nil {
+		return nil, err
+	}
+	ns, err := column.SubSet(r)
+	if err != nil {
+		return nil, err
+	}
+	return ns.At(0)
+}
+
+func (s *SyncDataFrame) SelectCols(names ...string) *DataFrame {
+	s.rlock()
+	defer s.mu.RUnlock()
+	return s.df.SelectCols(names...)
+}
+
+func (s *SyncDataFrame) SubSet(indexes ...int) (*DataFrame, error) {
+	s.rlock()
+	defer s.mu.RUnlock()
+	return s.df.SubSet(indexes...)
+}
+
+func (s *SyncDataFrame) Filter(filters ...F) (*DataFrame, error) {
+	s.rlock()
+	defer s.mu.RUnlock()
+	return s.df.Filter(filters...)
+}
+
+func (s *SyncDataFrame) Groups(names ...string) (map[string]*DataFrame, error) {
+	s.rlock()
+	defer s.mu.RUnlock()
+	return s.df.Groups(names...)
+}
+
+func (s *SyncDataFrame) Set(index int, values any) error {
+	return s.Write(func(df *DataFrame) error { return df.Set(index, values) })
+}
+
+func (s *SyncDataFrame) AddRows(values [][]any) error {
+	return s.Write(func(df *DataFrame) error { return df.AddRows(values) })
+}
+
+func (s *SyncDataFrame) AddCol(name string, values any, defaultValue any) error {
+	return s.Write(func(df *DataFrame) error { return df.AddCol(name, values, defaultValue) })
+}
+
+func (s *SyncDataFrame) Concat(x DataFrame, isColumn bool) error {
+	return s.Write(func(df *DataFrame) error { return df.Concat(x, isColumn) })
+}
+
+func (s *SyncDataFrame) DropCols(names ...string) {
+	_ = s.Write(func(df *DataFrame) error {
+		df.DropCols(names...)
+		return nil
+	})
+}
+
+func (s *SyncDataFrame) Rename(cols map[string]string) {
+	_ = s.Write(func(df *DataFrame) error {
+		df.Rename(cols)
+		return nil
+	})
+}
+
+func (s *SyncDataFrame) Arrange(order ...Order) error {
+	return s.Write(func(df *DataFrame) error { return df.Arrange(order...) })
+}
+
+func (s *SyncDataFrame) FormatCols(f func(index int, elem series.Element) series.Element, cols ...string) error {
+	return s.Write(func(df *DataFrame) error { return df.FormatCols(f, cols...) })
+}
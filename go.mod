@@ -7,6 +7,7 @@ require (
 	github.com/apcera/termtables v0.0.0-20170405184538-bcbc5dc54055
 	github.com/shopspring/decimal v1.4.0
 	github.com/xuri/excelize/v2 v2.8.1
+	golang.org/x/text v0.14.0
 )
 
 require (
@@ -19,5 +20,4 @@ require (
 	github.com/xuri/nfp v0.0.0-20240318013403-ab9948c2c4a7 // indirect
 	golang.org/x/crypto v0.19.0 // indirect
 	golang.org/x/net v0.21.0 // indirect
-	golang.org/x/text v0.14.0 // indirect
 )
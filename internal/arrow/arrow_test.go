@@ -0,0 +1,448 @@
+/**
+  Copyright (c) [2024] [JiangNan]
+  [pandas] is licensed under Mulan PSL v2.
+  You can use this software according to the terms and conditions of the Mulan PSL v2.
+  You may obtain a copy of Mulan PSL v2 at:
+           http://license.coscl.org.cn/MulanPSL2
+  THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND, EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT, MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+  See the Mulan PSL v2 for more details.
+*/
+
+package arrow
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"gitee.com/jn-qq/pandas/series"
+	"io"
+	"math"
+	"math/big"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// 按列读取全部记录批，不支持的列记为 nil 并返回其错误
+func readAll(r *Reader) (map[string][]any, map[string]error, error) {
+	values, errs := map[string][]any{}, map[string]error{}
+	for {
+		b, err := r.Next()
+		if err == io.EOF {
+			return values, errs, nil
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, c := range r.Columns() {
+			v, err := b.Column(c)
+			if err != nil {
+				errs[c.Name] = err
+				continue
+			}
+			values[c.Name] = append(values[c.Name], v...)
+		}
+	}
+}
+
+// testdata 中的 .golden 均为 Apache Arrow Go 读取对应文件得到的各列取值，见 testdata/README.md
+func TestGolden(t *testing.T) {
+	goldens, err := filepath.Glob("testdata/*.golden")
+	if err != nil || len(goldens) == 0 {
+		t.Fatal("没有 golden 文件", err)
+	}
+	for _, golden := range goldens {
+		name := strings.TrimSuffix(golden, ".golden")
+		for _, ext := range []string{".arrow", ".stream"} {
+			data, err := os.ReadFile(name + ext)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var r *Reader
+			if ext == ".arrow" {
+				r, err = NewFileReader(bytes.NewReader(data), int64(len(data)))
+			} else {
+				r, err = NewStreamReader(bytes.NewReader(data))
+			}
+			if err != nil {
+				t.Fatal(name+ext, err)
+			}
+			values, errs, err := readAll(r)
+			if err != nil {
+				t.Fatal(name+ext, err)
+			}
+			var got strings.Builder
+			for _, c := range r.Columns() {
+				if err := errs[c.Name]; err != nil {
+					if !errors.Is(err, series.ErrUnsupportedOperation) {
+						t.Errorf("%s%s: %s: %v", name, ext, c.Name, err)
+					}
+					fmt.Fprintf(&got, "%s\tunsupported\n", c.Name)
+					continue
+				}
+				fmt.Fprintf(&got, "%s\t%#v\n", c.Name, values[c.Name])
+			}
+			want, err := os.ReadFile(golden)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got.String() != string(want) {
+				t.Errorf("%s%s:\n%s\nwant:\n%s", name, ext, got.String(), want)
+			}
+		}
+	}
+}
+
+// 构造一条消息，legacy 为 true 时省略续接标识（0.15 之前的格式）
+func testMessage(kind uint8, header fbObject, body []byte, legacy bool) []byte {
+	meta := fbEncode(fbObject{metadataV5, kind, header, int64(len(body))})
+	for (8+len(meta))%8 != 0 {
+		meta = append(meta, 0)
+	}
+	var out []byte
+	if !legacy {
+		out = binary.LittleEndian.AppendUint32(out, continuation)
+	}
+	out = binary.LittleEndian.AppendUint32(out, uint32(len(meta)))
+	out = append(out, meta...)
+	return append(out, body...)
+}
+
+// 记录批的 FieldNode、Buffer 及消息体
+type testBatch struct {
+	nodes, buffers, body []byte
+}
+
+func (b *testBatch) node(length, nulls int) {
+	b.nodes = binary.LittleEndian.AppendUint64(b.nodes, uint64(length))
+	b.nodes = binary.LittleEndian.AppendUint64(b.nodes, uint64(nulls))
+}
+
+func (b *testBatch) buffer(data []byte) {
+	b.buffers = binary.LittleEndian.AppendUint64(b.buffers, uint64(len(b.body)))
+	b.buffers = binary.LittleEndian.AppendUint64(b.buffers, uint64(len(data)))
+	b.body = append(b.body, data...)
+	for len(b.body)%8 != 0 {
+		b.body = append(b.body, 0)
+	}
+}
+
+func (b *testBatch) header(rows int, variadic ...int64) fbObject {
+	header := fbObject{int64(rows), fbStructs{16, b.nodes}, fbStructs{16, b.buffers}, nil}
+	if variadic != nil {
+		var counts []byte
+		for _, n := range variadic {
+			counts = binary.LittleEndian.AppendUint64(counts, uint64(n))
+		}
+		header = append(header, fbStructs{8, counts})
+	}
+	return header
+}
+
+func littleEndian[T uint32 | uint64](values ...T) []byte {
+	var b []byte
+	for _, v := range values {
+		if x, ok := any(v).(uint32); ok {
+			b = binary.LittleEndian.AppendUint32(b, x)
+		} else {
+			b = binary.LittleEndian.AppendUint64(b, uint64(v))
+		}
+	}
+	return b
+}
+
+// 含字典（及增量字典）、LargeUtf8、Utf8View、LargeList 的流，Arrow Go 不支持写入其中部分类型，故手工构造
+func testStream(legacy bool) []byte {
+	schema := fbObject{nil, []fbObject{
+		{"d", true, typeUtf8, fbObject{}, fbObject{int64(7), fbObject{int32(8), true}}, []fbObject{}},
+		{"ls", true, typeLargeUtf8, fbObject{}, nil, []fbObject{}},
+		{"v", true, typeUtf8View, fbObject{}, nil, []fbObject{}},
+		{"ll", true, typeLargeList, fbObject{}, nil, []fbObject{
+			{"item", true, typeInt, fbObject{int32(16), true}, nil, []fbObject{}},
+		}},
+	}}
+	out := testMessage(headerSchema, schema, nil, legacy)
+	// 字典 ["x", "yy"]，增量字典 ["zzz"]
+	d := &testBatch{}
+	d.node(2, 0)
+	d.buffer(nil)
+	d.buffer(littleEndian[uint32](0, 1, 3))
+	d.buffer([]byte("xyy"))
+	out = append(out, testMessage(headerDictionaryBatch, fbObject{int64(7), d.header(2), false}, d.body, legacy)...)
+	d = &testBatch{}
+	d.node(1, 0)
+	d.buffer(nil)
+	d.buffer(littleEndian[uint32](0, 3))
+	d.buffer([]byte("zzz"))
+	out = append(out, testMessage(headerDictionaryBatch, fbObject{int64(7), d.header(1), true}, d.body, legacy)...)
+
+	b := &testBatch{}
+	b.node(3, 1) // d
+	b.buffer([]byte{0b101})
+	b.buffer([]byte{2, 0, 1})
+	b.node(3, 0) // ls
+	b.buffer(nil)
+	b.buffer(littleEndian[uint64](0, 2, 2, 5))
+	b.buffer([]byte("abcde"))
+	b.node(3, 0) // v：内联、引用数据缓冲区、空字符串
+	b.buffer(nil)
+	views := append(littleEndian[uint32](5), "short\x00\x00\x00\x00\x00\x00\x00"...)
+	views = append(views, append(littleEndian[uint32](14), "this"...)...)
+	views = append(views, littleEndian[uint32](0, 0)...)
+	views = append(views, make([]byte, 16)...)
+	b.buffer(views)
+	b.buffer([]byte("this is a long"))
+	b.node(3, 1) // ll
+	b.buffer([]byte{0b011})
+	b.buffer(littleEndian[uint64](0, 2, 3, 3))
+	b.node(3, 1) // ll.item
+	b.buffer([]byte{0b101})
+	b.buffer([]byte{1, 0, 0, 0, 0xfe, 0xff})
+	out = append(out, testMessage(headerRecordBatch, b.header(3, 1), b.body, legacy)...)
+	if !legacy {
+		out = append(out, littleEndian[uint32](continuation, 0)...)
+	}
+	return out
+}
+
+func TestStreamReader(t *testing.T) {
+	want := map[string][]any{
+		"d":  {"zzz", nil, "yy"},
+		"ls": {"ab", "", "cde"},
+		"v":  {"short", "this is a long", ""},
+		"ll": {[]any{1, nil}, []any{-2}, nil},
+	}
+	for _, legacy := range []bool{false, true} {
+		r, err := NewStreamReader(bytes.NewReader(testStream(legacy)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, errs, err := readAll(r)
+		if err != nil || len(errs) > 0 {
+			t.Fatal(err, errs)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("legacy=%v: %v", legacy, got)
+		}
+	}
+}
+
+// TestWriter 及 testdata/writer.* 使用的列及记录批
+var (
+	writerFields = []Field{
+		{Name: "i", Kind: KindInt},
+		{Name: "f", Kind: KindFloat},
+		{Name: "s", Kind: KindString},
+		{Name: "b", Kind: KindBool},
+		{Name: "li", Kind: KindInt, List: true},
+		{Name: "ls", Kind: KindString, List: true},
+		{Name: "lb", Kind: KindBool, List: true},
+		{Name: "lf", Kind: KindFloat, List: true},
+	}
+	writerBatches = [][][]any{
+		{
+			{1, nil, -3},
+			{1.5, 2.25, nil},
+			{"a", nil, "中文"},
+			{true, nil, false},
+			{[]any{1, nil, 3}, nil, []any{}},
+			{[]any{"x"}, []any{nil, "yy"}, nil},
+			{[]any{true, false, nil}, []any{}, nil},
+			{nil, []any{0.5}, []any{}},
+		},
+		{
+			{9, math.MaxInt64},
+			{nil, math.Inf(-1)},
+			{"", "\x00\n"},
+			{false, true},
+			{[]any{}, []any{math.MinInt64 + 1}},
+			{[]any{""}, nil},
+			{nil, []any{true}},
+			{[]any{nil}, []any{-0.25}},
+		},
+		{{}, {}, {}, {}, {}, {}, {}, {}},
+	}
+)
+
+// 写入 writerBatches，file 为 true 时为文件格式
+func writeBatches(t *testing.T, file bool) []byte {
+	var buf bytes.Buffer
+	var w *Writer
+	var err error
+	if file {
+		w, err = NewFileWriter(&buf, writerFields, "", nil)
+	} else {
+		w, err = NewStreamWriter(&buf, writerFields, "", nil)
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, batch := range writerBatches {
+		if err = w.WriteBatch(batch); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err = w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+// testdata/writer.arrow 及 writer.stream 由本包写入，writer.golden 为 Apache Arrow Go 读取的各列取值，
+// 写入结果须与之逐字节相同，保证 Arrow Go 可读取本包写入的数据
+func TestWriterGolden(t *testing.T) {
+	for _, ext := range []string{".arrow", ".stream"} {
+		want, err := os.ReadFile("testdata/writer" + ext)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := writeBatches(t, ext == ".arrow"); !bytes.Equal(got, want) {
+			t.Errorf("writer%s: 写入结果与 testdata 不同", ext)
+		}
+	}
+}
+
+func TestWriter(t *testing.T) {
+	fields, batches := writerFields, writerBatches
+	want := map[string][]any{}
+	for _, batch := range batches {
+		for i, column := range batch {
+			want[fields[i].Name] = append(want[fields[i].Name], column...)
+		}
+	}
+	for _, file := range []bool{false, true} {
+		data := writeBatches(t, file)
+		var r *Reader
+		var err error
+		if file {
+			r, err = NewFileReader(bytes.NewReader(data), int64(len(data)))
+		} else {
+			r, err = NewStreamReader(bytes.NewReader(data))
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i, c := range r.Columns() {
+			if c.Name != fields[i].Name || c.Kind != fields[i].Kind || c.List != fields[i].List {
+				t.Errorf("file=%v: 列 %d 为 %+v", file, i, c)
+			}
+		}
+		got, errs, err := readAll(r)
+		if err != nil || len(errs) > 0 {
+			t.Fatal(err, errs)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("file=%v: %v", file, got)
+		}
+	}
+
+	var buf bytes.Buffer
+	w, _ := NewStreamWriter(&buf, fields[:2], "", nil)
+	var mismatch *series.LengthMismatchError
+	if err := w.WriteBatch([][]any{{1, 2}, {1.5}}); !errors.As(err, &mismatch) {
+		t.Errorf("长度不一致：%v", err)
+	}
+	if err := w.WriteBatch([][]any{{"1"}, {1.5}}); !errors.Is(err, series.ErrTypeMismatch) {
+		t.Errorf("类型不匹配：%v", err)
+	}
+	if _, err := NewStreamWriter(&buf, fields, "gzip", nil); !errors.Is(err, series.ErrInvalidArgument) {
+		t.Errorf("压缩格式：%v", err)
+	}
+}
+
+// 损坏的数据返回错误，不会 panic 或按损坏的长度分配内存
+func TestCorrupt(t *testing.T) {
+	if _, err := NewStreamReader(strings.NewReader("")); !errors.Is(err, ErrFormat) {
+		t.Errorf("空数据：%v", err)
+	}
+	if _, err := NewFileReader(strings.NewReader("not arrow data"), 14); !errors.Is(err, ErrFormat) {
+		t.Errorf("非 Arrow 数据：%v", err)
+	}
+	file, err := os.ReadFile("testdata/structs.arrow")
+	if err != nil {
+		t.Fatal(err)
+	}
+	seeds := [][]byte{testStream(false), file}
+	read := func(data []byte, file bool) error {
+		var r *Reader
+		var err error
+		if file {
+			r, err = NewFileReader(bytes.NewReader(data), int64(len(data)))
+		} else {
+			r, err = NewStreamReader(bufio.NewReader(bytes.NewReader(data)))
+		}
+		if err != nil {
+			return err
+		}
+		_, _, err = readAll(r)
+		return err
+	}
+	valid := func(err error) bool {
+		return err == nil || errors.Is(err, series.ErrCorruptData) || errors.Is(err, series.ErrUnsupportedOperation) ||
+			errors.Is(err, io.ErrUnexpectedEOF)
+	}
+	for i, seed := range seeds {
+		for n := 0; n < len(seed); n++ {
+			if err := read(seed[:n], i == 1); err == nil && i == 1 || !valid(err) {
+				t.Errorf("截断至 %d 字节：%v", n, err)
+			}
+		}
+	}
+	rnd := rand.New(rand.NewSource(1))
+	for k := 0; k < 20000; k++ {
+		i := k % len(seeds)
+		data := bytes.Clone(seeds[i])
+		for j := rnd.Intn(4); j >= 0; j-- {
+			data[rnd.Intn(len(data))] = byte(rnd.Intn(256))
+		}
+		if err := read(data, i == 1); !valid(err) {
+			t.Fatalf("第 %d 次：%v", k, err)
+		}
+	}
+}
+
+func TestDecimalString(t *testing.T) {
+	tests := []struct {
+		x     string
+		scale int32
+		want  string
+	}{
+		{"12345", 2, "123.45"},
+		{"-5", 2, "-0.05"},
+		{"0", 2, "0.00"},
+		{"7", 0, "7"},
+		{"-12", -3, "-12000"},
+		{"0", -3, "0"},
+		{"1234567890123456789012345678901234567", 2, "12345678901234567890123456789012345.67"},
+	}
+	for _, test := range tests {
+		x, _ := new(big.Int).SetString(test.x, 10)
+		if got := decimalString(x, test.scale); got != test.want {
+			t.Errorf("decimalString(%s, %d) = %s，期望 %s", test.x, test.scale, got, test.want)
+		}
+	}
+}
+
+// 超出 time.Duration 范围（约 ±292 年）的时间
+func TestUnitTime(t *testing.T) {
+	tests := []struct {
+		v    int64
+		unit int16
+		want string
+	}{
+		{-62135596800, unitSecond, "0001-01-01T00:00:00Z"},
+		{-62135596800000, unitMilli, "0001-01-01T00:00:00Z"},
+		{253402300799999999, unitMicro, "9999-12-31T23:59:59.999999Z"},
+		{1, unitNano, "1970-01-01T00:00:00.000000001Z"},
+	}
+	for _, test := range tests {
+		if got := unitTime(test.v, test.unit).Format("2006-01-02T15:04:05.999999999Z07:00"); got != test.want {
+			t.Errorf("unitTime(%d, %d) = %s，期望 %s", test.v, test.unit, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,78 @@
+/**
+  Copyright (c) [2024] [JiangNan]
+  [pandas] is licensed under Mulan PSL v2.
+  You can use this software according to the terms and conditions of the Mulan PSL v2.
+  You may obtain a copy of Mulan PSL v2 at:
+           http://license.coscl.org.cn/MulanPSL2
+  THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND, EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT, MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+  See the Mulan PSL v2 for more details.
+*/
+
+package arrow
+
+import (
+	"gitee.com/jn-qq/pandas/series"
+)
+
+// 消息编号，消息语言由 series.SetLanguage 设置
+const (
+	MsgNotArrow          = "ArrowNotArrow"
+	MsgCorrupt           = "ArrowCorrupt"
+	MsgColumn            = "ArrowColumn"
+	MsgMessageType       = "ArrowMessageType"
+	MsgMetadataVersion   = "ArrowMetadataVersion"
+	MsgDictionaryID      = "ArrowDictionaryID"
+	MsgCodec             = "ArrowCodec"
+	MsgCompressionMethod = "ArrowCompressionMethod"
+	MsgType              = "ArrowType"
+	MsgBigEndian         = "ArrowBigEndian"
+	MsgListChildren      = "ArrowListChildren"
+	MsgNestedList        = "ArrowNestedList"
+	MsgTooLarge          = "ArrowTooLarge"
+)
+
+func init() {
+	series.RegisterMessages(series.Zh, map[string]string{
+		MsgNotArrow:          "不是 Arrow IPC 数据",
+		MsgCorrupt:           "Arrow 数据格式错误",
+		MsgColumn:            "Arrow 列 %s",
+		MsgMessageType:       "意外的消息类型 %d",
+		MsgMetadataVersion:   "不支持的元数据版本 %d",
+		MsgDictionaryID:      "未知的字典编号 %d",
+		MsgCodec:             "不支持的压缩格式 %v",
+		MsgCompressionMethod: "不支持的压缩方式 %d",
+		MsgType:              "不支持的数据类型 %d",
+		MsgBigEndian:         "不支持大端序数据",
+		MsgListChildren:      "列表须有且仅有一个子字段",
+		MsgNestedList:        "不支持嵌套列表",
+		MsgTooLarge:          "数据超过 2 GiB",
+	})
+	series.RegisterMessages(series.En, map[string]string{
+		MsgNotArrow:          "not arrow IPC data",
+		MsgCorrupt:           "invalid arrow data",
+		MsgColumn:            "arrow column %s",
+		MsgMessageType:       "unexpected message type %d",
+		MsgMetadataVersion:   "metadata version %d is not supported",
+		MsgDictionaryID:      "unknown dictionary id %d",
+		MsgCodec:             "unsupported compression codec %v",
+		MsgCompressionMethod: "unsupported compression method %d",
+		MsgType:              "type %d is not supported",
+		MsgBigEndian:         "big-endian data is not supported",
+		MsgListChildren:      "list must have one child field",
+		MsgNestedList:        "nested lists are not supported",
+		MsgTooLarge:          "data exceeds 2 GiB",
+	})
+}
+
+// 错误类型，数据损坏的错误均可通过 errors.Is(err, series.ErrCorruptData) 判断，
+// 不支持的列及压缩格式可通过 errors.Is(err, series.ErrUnsupportedOperation) 判断
+var (
+	// ErrFormat 不是合法的 Arrow IPC 数据
+	ErrFormat  = series.NewError(series.ErrCorruptData, MsgNotArrow)
+	errCorrupt = series.NewError(series.ErrCorruptData, MsgCorrupt)
+)
+
+// 为错误附加列名
+func columnError(err error, name string) error {
+	return series.NewError(err, MsgColumn, name)
+}
@@ -0,0 +1,289 @@
+/**
+  Copyright (c) [2024] [JiangNan]
+  [pandas] is licensed under Mulan PSL v2.
+  You can use this software according to the terms and conditions of the Mulan PSL v2.
+  You may obtain a copy of Mulan PSL v2 at:
+           http://license.coscl.org.cn/MulanPSL2
+  THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND, EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT, MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+  See the Mulan PSL v2 for more details.
+*/
+
+package arrow
+
+import (
+	"encoding/binary"
+)
+
+// 中止解析时携带的错误
+type abort struct {
+	err error
+}
+
+// 以 err 中止解析，由 catch 转换为返回的错误
+func fail(err error) {
+	panic(abort{err})
+}
+
+func catch(err *error) {
+	if r := recover(); r != nil {
+		a, ok := r.(abort)
+		if !ok {
+			panic(r)
+		}
+		*err = a.err
+	}
+}
+
+// 返回 b[pos:pos+n]，越界时中止解析
+func slice(b []byte, pos, n int) []byte {
+	if pos < 0 || n < 0 || pos > len(b) || n > len(b)-pos {
+		fail(errCorrupt)
+	}
+	return b[pos : pos+n]
+}
+
+func u16(b []byte, pos int) int {
+	return int(binary.LittleEndian.Uint16(slice(b, pos, 2)))
+}
+
+func u32(b []byte, pos int) uint32 {
+	return binary.LittleEndian.Uint32(slice(b, pos, 4))
+}
+
+func u64(b []byte, pos int) uint64 {
+	return binary.LittleEndian.Uint64(slice(b, pos, 8))
+}
+
+// FlatBuffers 表
+type fbTable struct {
+	buf []byte
+	pos int
+}
+
+// 返回根表
+func fbRoot(buf []byte) fbTable {
+	return fbTable{buf, int(u32(buf, 0))}
+}
+
+// 返回字段的绝对位置，字段缺省时为 0
+func (t fbTable) field(id int) int {
+	vtable := t.pos - int(int32(u32(t.buf, t.pos)))
+	size := u16(t.buf, vtable)
+	if 4+2*id+2 > size {
+		return 0
+	}
+	if off := u16(t.buf, vtable+4+2*id); off != 0 {
+		return t.pos + off
+	}
+	return 0
+}
+
+func (t fbTable) uint8(id int, def uint8) uint8 {
+	if p := t.field(id); p != 0 {
+		return slice(t.buf, p, 1)[0]
+	}
+	return def
+}
+
+func (t fbTable) bool(id int) bool {
+	return t.uint8(id, 0) != 0
+}
+
+func (t fbTable) int16(id int, def int16) int16 {
+	if p := t.field(id); p != 0 {
+		return int16(u16(t.buf, p))
+	}
+	return def
+}
+
+func (t fbTable) int32(id int, def int32) int32 {
+	if p := t.field(id); p != 0 {
+		return int32(u32(t.buf, p))
+	}
+	return def
+}
+
+func (t fbTable) int64(id int, def int64) int64 {
+	if p := t.field(id); p != 0 {
+		return int64(u64(t.buf, p))
+	}
+	return def
+}
+
+// 返回偏移量指向的位置
+func (t fbTable) deref(p int) int {
+	return p + int(u32(t.buf, p))
+}
+
+func (t fbTable) table(id int) (fbTable, bool) {
+	if p := t.field(id); p != 0 {
+		return fbTable{t.buf, t.deref(p)}, true
+	}
+	return fbTable{}, false
+}
+
+func (t fbTable) string(id int) string {
+	if p := t.field(id); p != 0 {
+		p = t.deref(p)
+		return string(slice(t.buf, p+4, int(u32(t.buf, p))))
+	}
+	return ""
+}
+
+// 返回向量元素的起始位置及个数
+func (t fbTable) vector(id int) (int, int) {
+	if p := t.field(id); p != 0 {
+		p = t.deref(p)
+		return p + 4, int(u32(t.buf, p))
+	}
+	return 0, 0
+}
+
+func (t fbTable) tables(id int) []fbTable {
+	start, n := t.vector(id)
+	slice(t.buf, start, 4*n)
+	tables := make([]fbTable, 0, n)
+	for i := 0; i < n; i++ {
+		tables = append(tables, fbTable{t.buf, t.deref(start + 4*i)})
+	}
+	return tables
+}
+
+// 返回元素大小为 size 的结构体向量的数据
+func (t fbTable) structs(id, size int) []byte {
+	start, n := t.vector(id)
+	if n > len(t.buf)/size {
+		fail(errCorrupt)
+	}
+	return slice(t.buf, start, n*size)
+}
+
+// 待编码的 FlatBuffers 表，按字段序号排列，nil 为缺省字段。字段值可为 bool、uint8、int16、int32、int64、
+// string、fbObject、[]fbObject 或 fbStructs
+type fbObject []any
+
+// 结构体向量，每个元素 size 字节并按 8 字节对齐
+type fbStructs struct {
+	size int
+	data []byte
+}
+
+// 将根表编码为 FlatBuffers 数据。各对象按先父后子的顺序写入，偏移量均指向更高的地址
+func fbEncode(root fbObject) []byte {
+	b := &fbBuilder{buf: make([]byte, 4, 256)}
+	binary.LittleEndian.PutUint32(b.buf, uint32(b.object(root)))
+	return b.buf
+}
+
+type fbBuilder struct {
+	buf []byte
+}
+
+func (b *fbBuilder) pad(align int) {
+	for len(b.buf)%align != 0 {
+		b.buf = append(b.buf, 0)
+	}
+}
+
+// 字段在表中占用的字节数，引用类型为 4 字节偏移量
+func fbSize(v any) int {
+	switch v.(type) {
+	case bool, uint8:
+		return 1
+	case int16:
+		return 2
+	case int64:
+		return 8
+	default:
+		return 4
+	}
+}
+
+// 写入虚表及表，返回表的位置
+func (b *fbBuilder) object(fields fbObject) int {
+	b.pad(2)
+	vtable := len(b.buf)
+	b.buf = append(b.buf, make([]byte, 4+2*len(fields))...)
+	// 表以 4 字节的虚表偏移量开头，起始于 8 字节边界后 4 字节处，使其后的 8 字节字段对齐
+	for len(b.buf)%8 != 4 {
+		b.buf = append(b.buf, 0)
+	}
+	start := len(b.buf)
+	b.buf = binary.LittleEndian.AppendUint32(b.buf, uint32(start-vtable))
+	type ref struct {
+		at    int
+		value any
+	}
+	var refs []ref
+	for _, size := range []int{8, 4, 2, 1} {
+		for id, v := range fields {
+			if v == nil || fbSize(v) != size {
+				continue
+			}
+			b.pad(size)
+			binary.LittleEndian.PutUint16(b.buf[vtable+4+2*id:], uint16(len(b.buf)-start))
+			switch x := v.(type) {
+			case bool:
+				if x {
+					b.buf = append(b.buf, 1)
+				} else {
+					b.buf = append(b.buf, 0)
+				}
+			case uint8:
+				b.buf = append(b.buf, x)
+			case int16:
+				b.buf = binary.LittleEndian.AppendUint16(b.buf, uint16(x))
+			case int32:
+				b.buf = binary.LittleEndian.AppendUint32(b.buf, uint32(x))
+			case int64:
+				b.buf = binary.LittleEndian.AppendUint64(b.buf, uint64(x))
+			default:
+				refs = append(refs, ref{len(b.buf), v})
+				b.buf = append(b.buf, 0, 0, 0, 0)
+			}
+		}
+	}
+	binary.LittleEndian.PutUint16(b.buf[vtable:], uint16(4+2*len(fields)))
+	binary.LittleEndian.PutUint16(b.buf[vtable+2:], uint16(len(b.buf)-start))
+	for _, r := range refs {
+		b.ref(r.at, b.value(r.value))
+	}
+	return start
+}
+
+// 在 at 处写入指向 pos 的偏移量
+func (b *fbBuilder) ref(at, pos int) {
+	binary.LittleEndian.PutUint32(b.buf[at:], uint32(pos-at))
+}
+
+// 写入引用类型的值，返回其位置
+func (b *fbBuilder) value(v any) int {
+	switch x := v.(type) {
+	case fbObject:
+		return b.object(x)
+	case string:
+		b.pad(4)
+		pos := len(b.buf)
+		b.buf = binary.LittleEndian.AppendUint32(b.buf, uint32(len(x)))
+		b.buf = append(append(b.buf, x...), 0)
+		return pos
+	case []fbObject:
+		b.pad(4)
+		pos := len(b.buf)
+		b.buf = binary.LittleEndian.AppendUint32(b.buf, uint32(len(x)))
+		b.buf = append(b.buf, make([]byte, 4*len(x))...)
+		for i, object := range x {
+			b.ref(pos+4+4*i, b.object(object))
+		}
+		return pos
+	case fbStructs:
+		for len(b.buf)%8 != 4 {
+			b.buf = append(b.buf, 0)
+		}
+		pos := len(b.buf)
+		b.buf = binary.LittleEndian.AppendUint32(b.buf, uint32(len(x.data)/x.size))
+		b.buf = append(b.buf, x.data...)
+		return pos
+	}
+	panic("arrow: unsupported flatbuffer value")
+}
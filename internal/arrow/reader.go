@@ -0,0 +1,758 @@
+/**
+  Copyright (c) [2024] [JiangNan]
+  [pandas] is licensed under Mulan PSL v2.
+  You can use this software according to the terms and conditions of the Mulan PSL v2.
+  You may obtain a copy of Mulan PSL v2 at:
+           http://license.coscl.org.cn/MulanPSL2
+  THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND, EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT, MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+  See the Mulan PSL v2 for more details.
+*/
+
+package arrow
+
+import (
+	"bytes"
+	"encoding/binary"
+	"gitee.com/jn-qq/pandas/series"
+	"io"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// 消息前的续接标识
+const continuation = 0xFFFFFFFF
+
+// Decompressor 解压缓冲区，codec 为 "lz4"（LZ4 帧格式）或 "zstd"，size 为解压后的大小
+type Decompressor func(codec string, data []byte, size int) ([]byte, error)
+
+// Reader Arrow IPC 流格式或文件格式的记录批读取器
+type Reader struct {
+	// Decompress 解压压缩的记录批，为 nil 时读取压缩的记录批返回错误
+	Decompress Decompressor
+
+	fields     []*field
+	columns    []*Column
+	dictFields map[int64]*field
+	dicts      map[int64][]any
+	version    int16
+	next       func() (*message, error)
+}
+
+// 一条 IPC 消息
+type message struct {
+	version int16
+	kind    uint8
+	header  fbTable
+	body    []byte
+}
+
+// NewStreamReader 读取流格式的模式，之后由 Next 依次读取记录批
+func NewStreamReader(r io.Reader) (*Reader, error) {
+	msg, err := readMessage(r)
+	if err == io.EOF {
+		return nil, ErrFormat
+	}
+	if err != nil {
+		return nil, err
+	}
+	ar, err := newReader(msg.version, msg.kind, msg.header)
+	if err != nil {
+		return nil, err
+	}
+	ar.next = func() (*message, error) { return readMessage(r) }
+	return ar, nil
+}
+
+// NewFileReader 读取文件格式的尾部、模式及字典，size 为文件大小
+func NewFileReader(r io.ReaderAt, size int64) (*Reader, error) {
+	if size < int64(2*len(magic)+6) {
+		return nil, ErrFormat
+	}
+	var tail [10]byte
+	if _, err := r.ReadAt(tail[:], size-10); err != nil {
+		return nil, err
+	}
+	footerSize := int64(binary.LittleEndian.Uint32(tail[:]))
+	if string(tail[4:]) != magic || footerSize > size-10-8 {
+		return nil, ErrFormat
+	}
+	footer := make([]byte, footerSize)
+	if _, err := r.ReadAt(footer, size-10-footerSize); err != nil {
+		return nil, err
+	}
+	version, schema, dicts, batches, err := parseFooter(footer)
+	if err != nil {
+		return nil, err
+	}
+	ar, err := newReader(version, headerSchema, schema)
+	if err != nil {
+		return nil, err
+	}
+	for i := 0; i < len(dicts); i += 24 {
+		msg, err := readBlock(r, dicts[i:])
+		if err != nil {
+			return nil, err
+		}
+		if msg.kind != headerDictionaryBatch {
+			return nil, series.NewError(series.ErrCorruptData, MsgMessageType, msg.kind)
+		}
+		if err = ar.readDictionary(msg); err != nil {
+			return nil, err
+		}
+	}
+	ar.next = func() (*message, error) {
+		if len(batches) == 0 {
+			return nil, io.EOF
+		}
+		msg, err := readBlock(r, batches)
+		batches = batches[24:]
+		return msg, err
+	}
+	return ar, nil
+}
+
+// 解析文件尾部，返回版本、模式及字典批、记录批的 Block 结构体数据
+func parseFooter(footer []byte) (version int16, schema fbTable, dicts, batches []byte, err error) {
+	defer catch(&err)
+	t := fbRoot(footer)
+	schema, ok := t.table(1)
+	if !ok {
+		return 0, schema, nil, nil, ErrFormat
+	}
+	return t.int16(0, 0), schema, t.structs(2, 24), t.structs(3, 24), nil
+}
+
+// 按 Block 结构体（偏移量、元数据长度、消息体长度）读取消息
+func readBlock(r io.ReaderAt, block []byte) (*message, error) {
+	offset := int64(binary.LittleEndian.Uint64(block))
+	metaSize := int64(int32(binary.LittleEndian.Uint32(block[8:])))
+	bodySize := int64(binary.LittleEndian.Uint64(block[16:]))
+	if offset < 0 || metaSize < 0 || bodySize < 0 {
+		return nil, errCorrupt
+	}
+	msg, err := readMessage(io.NewSectionReader(r, offset, metaSize+bodySize))
+	if err == io.EOF {
+		err = errCorrupt
+	}
+	return msg, err
+}
+
+// 读取一条消息，遇到流结束标识或数据结束时返回 io.EOF
+func readMessage(r io.Reader) (*message, error) {
+	var prefix [4]byte
+	if _, err := io.ReadFull(r, prefix[:]); err != nil {
+		return nil, err
+	}
+	size := binary.LittleEndian.Uint32(prefix[:])
+	// 0.15 之前的版本没有续接标识
+	if size == continuation {
+		if _, err := io.ReadFull(r, prefix[:]); err != nil {
+			return nil, unexpected(err)
+		}
+		size = binary.LittleEndian.Uint32(prefix[:])
+	}
+	if size == 0 {
+		return nil, io.EOF
+	}
+	meta, err := readN(r, int64(size))
+	if err != nil {
+		return nil, err
+	}
+	msg, bodySize, err := parseMessage(meta)
+	if err != nil {
+		return nil, err
+	}
+	if msg.body, err = readN(r, bodySize); err != nil {
+		return nil, err
+	}
+	return msg, nil
+}
+
+func parseMessage(meta []byte) (msg *message, bodySize int64, err error) {
+	defer catch(&err)
+	t := fbRoot(meta)
+	header, ok := t.table(2)
+	bodySize = t.int64(3, 0)
+	if !ok || bodySize < 0 {
+		return nil, 0, errCorrupt
+	}
+	return &message{version: t.int16(0, 0), kind: t.uint8(1, 0), header: header}, bodySize, nil
+}
+
+// 读取 n 个字节，按实际读取的数据分配内存
+func readN(r io.Reader, n int64) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.Grow(int(min(n, 1<<20)))
+	if _, err := io.CopyN(&buf, r, n); err != nil {
+		return nil, unexpected(err)
+	}
+	return buf.Bytes(), nil
+}
+
+func unexpected(err error) error {
+	if err == io.EOF {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
+
+func newReader(version int16, kind uint8, schema fbTable) (*Reader, error) {
+	if kind != headerSchema {
+		return nil, ErrFormat
+	}
+	if version < metadataV4 {
+		return nil, series.NewError(series.ErrUnsupportedOperation, MsgMetadataVersion, version)
+	}
+	fields, err := parseSchema(schema)
+	if err != nil {
+		return nil, err
+	}
+	r := &Reader{
+		fields:     fields,
+		columns:    newColumns(fields),
+		dictFields: map[int64]*field{},
+		dicts:      map[int64][]any{},
+		version:    version,
+	}
+	var walk func(f *field)
+	walk = func(f *field) {
+		if f.index != nil {
+			r.dictFields[f.dictID] = f
+		}
+		for _, child := range f.children {
+			walk(child)
+		}
+	}
+	for _, f := range fields {
+		walk(f)
+	}
+	return r, nil
+}
+
+// Columns 返回可读取的列
+func (r *Reader) Columns() []*Column {
+	return r.columns
+}
+
+// Next 读取下一个记录批，没有更多数据时返回 io.EOF，其间的字典批用于更新字典
+func (r *Reader) Next() (*Batch, error) {
+	for {
+		msg, err := r.next()
+		if err != nil {
+			return nil, err
+		}
+		switch msg.kind {
+		case headerDictionaryBatch:
+			if err = r.readDictionary(msg); err != nil {
+				return nil, err
+			}
+		case headerRecordBatch:
+			return r.newBatch(msg.header, msg.body, r.fields)
+		default:
+			return nil, series.NewError(series.ErrCorruptData, MsgMessageType, msg.kind)
+		}
+	}
+}
+
+// 读取字典批，替换或追加至字典
+func (r *Reader) readDictionary(msg *message) (err error) {
+	defer catch(&err)
+	id := msg.header.int64(0, 0)
+	f, ok := r.dictFields[id]
+	if !ok {
+		return series.NewError(series.ErrCorruptData, MsgDictionaryID, id)
+	}
+	data, ok := msg.header.table(1)
+	if !ok {
+		return errCorrupt
+	}
+	// 字典批只有一列，类型为字段的值类型
+	value := *f
+	value.index = nil
+	b, err := r.newBatch(data, msg.body, []*field{&value})
+	if err != nil {
+		return err
+	}
+	values := b.decode(&value)
+	if msg.header.bool(2) {
+		r.dicts[id] = append(r.dicts[id], values...)
+	} else {
+		r.dicts[id] = values
+	}
+	return nil
+}
+
+// Batch 记录批
+type Batch struct {
+	r        *Reader
+	rows     int
+	nodes    []byte // FieldNode 结构体数据
+	buffers  []byte // Buffer 结构体数据
+	body     []byte
+	codec    string
+	layout   map[*field][2]int // 字段的 FieldNode 序号及首个缓冲区序号
+	variadic map[*field]int    // 视图类型字段的数据缓冲区个数
+	cache    map[int][]byte    // 已解压的缓冲区
+}
+
+func (r *Reader) newBatch(t fbTable, body []byte, fields []*field) (b *Batch, err error) {
+	defer catch(&err)
+	b = &Batch{
+		r:        r,
+		rows:     int(t.int64(0, 0)),
+		nodes:    t.structs(1, 16),
+		buffers:  t.structs(2, 16),
+		body:     body,
+		layout:   map[*field][2]int{},
+		variadic: map[*field]int{},
+	}
+	if b.rows < 0 {
+		return nil, errCorrupt
+	}
+	if compression, ok := t.table(3); ok {
+		switch compression.uint8(0, 0) {
+		case 0:
+			b.codec = "lz4"
+		case 1:
+			b.codec = "zstd"
+		default:
+			return nil, series.NewError(series.ErrUnsupportedOperation, MsgCodec, compression.uint8(0, 0))
+		}
+		if compression.uint8(1, 0) != 0 {
+			return nil, series.NewError(series.ErrUnsupportedOperation, MsgCompressionMethod, compression.uint8(1, 0))
+		}
+		if r.Decompress == nil {
+			return nil, series.NewError(series.ErrUnsupportedOperation, MsgCodec, b.codec)
+		}
+		b.cache = map[int][]byte{}
+	}
+	start, n := t.vector(4)
+	counts := slice(t.buf, start, 8*n)
+	var node, buffer int
+	var walk func(f *field)
+	walk = func(f *field) {
+		b.layout[f] = [2]int{node, buffer}
+		node++
+		if f.index != nil {
+			buffer += 2
+			return
+		}
+		switch f.typ {
+		case typeNull, typeRunEndEncoded:
+		case typeStruct, typeFixedSizeList:
+			buffer++
+		case typeUnion:
+			buffer++
+			if f.dense {
+				buffer++
+			}
+			// V4 中联合类型有有效位图
+			if r.version < metadataV5 {
+				buffer++
+			}
+		case typeBinary, typeUtf8, typeLargeBinary, typeLargeUtf8, typeListView, typeLargeListView:
+			buffer += 3
+		case typeBinaryView, typeUtf8View:
+			if len(counts) < 8 {
+				fail(errCorrupt)
+			}
+			count := int64(binary.LittleEndian.Uint64(counts))
+			if count < 0 || count > int64(len(b.buffers)) {
+				fail(errCorrupt)
+			}
+			counts = counts[8:]
+			b.variadic[f] = int(count)
+			buffer += 2 + int(count)
+		default:
+			buffer += 2
+		}
+		for _, child := range f.children {
+			walk(child)
+		}
+	}
+	for _, f := range fields {
+		walk(f)
+	}
+	return b, nil
+}
+
+// Len 返回行数
+func (b *Batch) Len() int {
+	return b.rows
+}
+
+// Column 读取列在该记录批中的值，每行一个值：空值为 nil，
+// 基本类型列为 int、float64、string 或 bool，列表列为 []any
+func (b *Batch) Column(c *Column) (values []any, err error) {
+	if c.Err != nil {
+		return nil, c.Err
+	}
+	defer catch(&err)
+	values = b.decode(c.path[len(c.path)-1])
+	// 结构体为空值时其子字段均为空值
+	for _, f := range c.path[:len(c.path)-1] {
+		if _, nulls := b.node(f); nulls > 0 {
+			valid := b.buffer(b.layout[f][1])
+			for i := range values {
+				if !bit(valid, i) {
+					values[i] = nil
+				}
+			}
+		}
+	}
+	if len(values) < b.rows {
+		return nil, errCorrupt
+	}
+	return values[:b.rows], nil
+}
+
+// 返回字段的长度及空值个数
+func (b *Batch) node(f *field) (int, int) {
+	i := b.layout[f][0]
+	length := int64(u64(b.nodes, 16*i))
+	nulls := int64(u64(b.nodes, 16*i+8))
+	if length < 0 || length > math.MaxInt32 || nulls < 0 {
+		fail(errCorrupt)
+	}
+	return int(length), int(nulls)
+}
+
+// 返回第 i 个缓冲区的数据，压缩的缓冲区解压后返回
+func (b *Batch) buffer(i int) []byte {
+	offset := int64(u64(b.buffers, 16*i))
+	length := int64(u64(b.buffers, 16*i+8))
+	if offset < 0 || length < 0 || offset > int64(len(b.body)) || length > int64(len(b.body)) {
+		fail(errCorrupt)
+	}
+	data := slice(b.body, int(offset), int(length))
+	if b.codec == "" || len(data) == 0 {
+		return data
+	}
+	if out, ok := b.cache[i]; ok {
+		return out
+	}
+	// 压缩的缓冲区以 8 字节的解压后长度开头，-1 表示未压缩
+	size := int64(u64(data, 0))
+	out := data[8:]
+	if size != -1 {
+		if size < 0 || size > math.MaxInt32 {
+			fail(errCorrupt)
+		}
+		var err error
+		if out, err = b.r.Decompress(b.codec, out, int(size)); err != nil {
+			fail(err)
+		}
+		if int64(len(out)) != size {
+			fail(errCorrupt)
+		}
+	}
+	b.cache[i] = out
+	return out
+}
+
+// 有效位图中第 i 位是否为 1
+func bit(bitmap []byte, i int) bool {
+	return slice(bitmap, i/8, 1)[0]>>(i%8)&1 == 1
+}
+
+// 解码字段的全部值，空值为 nil。分配内存前先按缓冲区大小校验长度
+func (b *Batch) decode(f *field) []any {
+	n, nulls := b.node(f)
+	buffer := b.layout[f][1]
+	if f.typ == typeNull && f.index == nil {
+		if n > b.rows {
+			fail(errCorrupt)
+		}
+		return make([]any, n)
+	}
+	valid := func(int) bool { return true }
+	if nulls > 0 {
+		bitmap := slice(b.buffer(buffer), 0, (n+7)/8)
+		valid = func(i int) bool { return bit(bitmap, i) }
+	}
+	var values []any
+	if f.index != nil {
+		dict := b.r.dicts[f.dictID]
+		width := f.index.bitWidth / 8
+		if width < 1 || width > 8 {
+			fail(errCorrupt)
+		}
+		data := slice(b.buffer(buffer+1), 0, n*width)
+		values = make([]any, n)
+		for i := range values {
+			if valid(i) {
+				k := toInt(data[i*width:(i+1)*width], f.index.signed)
+				if k < 0 || k >= len(dict) {
+					fail(errCorrupt)
+				}
+				values[i] = dict[k]
+			}
+		}
+		return values
+	}
+	switch f.typ {
+	case typeBool:
+		data := slice(b.buffer(buffer+1), 0, (n+7)/8)
+		values = make([]any, n)
+		for i := range values {
+			if valid(i) {
+				values[i] = bit(data, i)
+			}
+		}
+	case typeBinary, typeUtf8, typeLargeBinary, typeLargeUtf8:
+		width := 4
+		if f.typ == typeLargeBinary || f.typ == typeLargeUtf8 {
+			width = 8
+		}
+		offsets, data := slice(b.buffer(buffer+1), 0, (n+1)*width), b.buffer(buffer+2)
+		values = make([]any, n)
+		for i := range values {
+			if valid(i) {
+				start, end := offset(offsets, i, width), offset(offsets, i+1, width)
+				values[i] = string(slice(data, start, end-start))
+			}
+		}
+	case typeBinaryView, typeUtf8View:
+		views := slice(b.buffer(buffer+1), 0, 16*n)
+		values = make([]any, n)
+		for i := range values {
+			if !valid(i) {
+				continue
+			}
+			view := views[16*i : 16*(i+1)]
+			length := int(int32(binary.LittleEndian.Uint32(view)))
+			if length <= 12 {
+				values[i] = string(slice(view, 4, length))
+				continue
+			}
+			index := int(binary.LittleEndian.Uint32(view[8:]))
+			if index >= b.variadic[f] {
+				fail(errCorrupt)
+			}
+			values[i] = string(slice(b.buffer(buffer+2+index), int(binary.LittleEndian.Uint32(view[12:])), length))
+		}
+	case typeList, typeLargeList:
+		width := 4
+		if f.typ == typeLargeList {
+			width = 8
+		}
+		offsets := slice(b.buffer(buffer+1), 0, (n+1)*width)
+		items := b.decode(child(f))
+		values = make([]any, n)
+		for i := range values {
+			if valid(i) {
+				start, end := offset(offsets, i, width), offset(offsets, i+1, width)
+				if start > end || end > len(items) {
+					fail(errCorrupt)
+				}
+				values[i] = append([]any{}, items[start:end]...)
+			}
+		}
+	case typeFixedSizeList:
+		items := b.decode(child(f))
+		if int64(f.size)*int64(n) > int64(len(items)) || f.size == 0 && n > b.rows {
+			fail(errCorrupt)
+		}
+		values = make([]any, n)
+		for i := range values {
+			if valid(i) {
+				values[i] = append([]any{}, items[i*f.size:(i+1)*f.size]...)
+			}
+		}
+	default:
+		width := fixedWidth(f)
+		if width <= 0 {
+			fail(columnError(series.NewError(series.ErrUnsupportedOperation, MsgType, f.typ), f.name))
+		}
+		data := slice(b.buffer(buffer+1), 0, n*width)
+		values = make([]any, n)
+		for i := range values {
+			if valid(i) {
+				values[i] = fixedValue(f, data[i*width:(i+1)*width])
+			}
+		}
+	}
+	return values
+}
+
+// 返回列表字段的元素字段
+func child(f *field) *field {
+	if len(f.children) != 1 {
+		fail(errCorrupt)
+	}
+	return f.children[0]
+}
+
+// 返回第 i 个偏移量
+func offset(offsets []byte, i, width int) int {
+	var v int64
+	if width == 4 {
+		v = int64(int32(u32(offsets, 4*i)))
+	} else {
+		v = int64(u64(offsets, 8*i))
+	}
+	if v < 0 || v > math.MaxInt32 {
+		fail(errCorrupt)
+	}
+	return int(v)
+}
+
+// 定长类型每个值的字节数，其他类型返回 0
+func fixedWidth(f *field) int {
+	switch f.typ {
+	case typeInt, typeTime, typeDecimal:
+		if f.bitWidth%8 != 0 {
+			return 0
+		}
+		return f.bitWidth / 8
+	case typeFloatingPoint:
+		if f.precision < precisionHalf || f.precision > precisionDouble {
+			return 0
+		}
+		return 2 << f.precision
+	case typeDate:
+		if f.unit == 0 {
+			return 4
+		}
+		return 8
+	case typeTimestamp, typeDuration:
+		return 8
+	case typeFixedSizeBinary:
+		return f.size
+	}
+	return 0
+}
+
+// 以小端序读取整数，不超过 8 字节
+func toInt(b []byte, signed bool) int {
+	var v uint64
+	for i := len(b) - 1; i >= 0; i-- {
+		v = v<<8 | uint64(b[i])
+	}
+	if signed && len(b) < 8 && b[len(b)-1]&0x80 != 0 {
+		v |= math.MaxUint64 << (8 * len(b))
+	}
+	return int(v)
+}
+
+// 解码一个定长值
+func fixedValue(f *field, b []byte) any {
+	switch f.typ {
+	case typeInt:
+		if len(b) > 8 {
+			fail(errCorrupt)
+		}
+		return toInt(b, f.signed)
+	case typeDuration:
+		return toInt(b, true)
+	case typeFloatingPoint:
+		switch f.precision {
+		case precisionHalf:
+			return shortFloat(halfToFloat(binary.LittleEndian.Uint16(b)))
+		case precisionSingle:
+			return shortFloat(math.Float32frombits(binary.LittleEndian.Uint32(b)))
+		default:
+			return math.Float64frombits(binary.LittleEndian.Uint64(b))
+		}
+	case typeDecimal:
+		// 小端序的补码
+		be := make([]byte, len(b))
+		for i := range b {
+			be[len(b)-1-i] = b[i]
+		}
+		x := new(big.Int).SetBytes(be)
+		if len(be) > 0 && be[0]&0x80 != 0 {
+			x.Sub(x, new(big.Int).Lsh(big.NewInt(1), uint(8*len(be))))
+		}
+		if !floatDecimal(f) {
+			return decimalString(x, f.scale)
+		}
+		v, _ := strconv.ParseFloat(decimalString(x, f.scale), 64)
+		return v
+	case typeDate:
+		v := int64(toInt(b, true))
+		if f.unit == 0 {
+			return time.Unix(v*86400, 0).UTC().Format(time.DateOnly)
+		}
+		return time.UnixMilli(v).UTC().Format(time.DateOnly)
+	case typeTime:
+		v := int64(toInt(b, true))
+		return unitTime(v, f.unit).Format("15:04:05.999999999")
+	case typeTimestamp:
+		return unitTime(int64(toInt(b, true)), f.unit).Format(time.RFC3339Nano)
+	default:
+		return string(b)
+	}
+}
+
+// 自 1970-01-01 UTC 起 v 个 unit 的时间，不经过 time.Duration，超出约 ±292 年时不会溢出
+func unitTime(v int64, unit int16) time.Time {
+	switch unit {
+	case unitSecond:
+		return time.Unix(v, 0).UTC()
+	case unitMilli:
+		return time.UnixMilli(v).UTC()
+	case unitMicro:
+		return time.UnixMicro(v).UTC()
+	default:
+		return time.Unix(0, v).UTC()
+	}
+}
+
+// float64 可无损还原的十进制有效位数
+const maxFloatDigits = 15
+
+// 小数位数绝对值的上限，防止损坏的数据导致生成过长的字符串
+const maxScale = 1000
+
+// Decimal 是否读取为 float64，有效位数超过 maxFloatDigits 时读取为精确的十进制字符串
+func floatDecimal(f *field) bool {
+	return f.digits > 0 && f.digits <= maxFloatDigits
+}
+
+// x × 10^-scale 的精确十进制表示
+func decimalString(x *big.Int, scale int32) string {
+	digits, sign := x.String(), ""
+	if digits[0] == '-' {
+		sign, digits = "-", digits[1:]
+	}
+	switch {
+	case scale == 0 || scale < 0 && digits == "0":
+		return sign + digits
+	case scale < 0:
+		return sign + digits + strings.Repeat("0", int(-scale))
+	}
+	if n := int(scale) + 1 - len(digits); n > 0 {
+		digits = strings.Repeat("0", n) + digits
+	}
+	point := len(digits) - int(scale)
+	return sign + digits[:point] + "." + digits[point:]
+}
+
+// 转换为 float64，保留 float32 的最短十进制表示
+func shortFloat(f float32) float64 {
+	v, _ := strconv.ParseFloat(strconv.FormatFloat(float64(f), 'g', -1, 32), 64)
+	return v
+}
+
+// 半精度浮点数转换为 float32
+func halfToFloat(h uint16) float32 {
+	sign := uint32(h>>15) << 31
+	exp := uint32(h>>10) & 0x1f
+	frac := uint32(h & 0x3ff)
+	switch exp {
+	case 0x1f:
+		return math.Float32frombits(sign | 0x7f800000 | frac<<13)
+	case 0:
+		// 非规格化数为 frac × 2^-24
+		f := float32(frac) / (1 << 24)
+		if sign != 0 {
+			f = -f
+		}
+		return f
+	}
+	return math.Float32frombits(sign | (exp+112)<<23 | frac<<13)
+}
@@ -0,0 +1,251 @@
+/**
+  Copyright (c) [2024] [JiangNan]
+  [pandas] is licensed under Mulan PSL v2.
+  You can use this software according to the terms and conditions of the Mulan PSL v2.
+  You may obtain a copy of Mulan PSL v2 at:
+           http://license.coscl.org.cn/MulanPSL2
+  THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND, EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT, MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+  See the Mulan PSL v2 for more details.
+*/
+
+// Package arrow 实现 Apache Arrow IPC 流格式及文件格式（Feather V2）中 dataframe 所需的部分：
+// 基本类型列、列表列、结构体列及字典编码列的读取，以及基本类型列与列表列的写入
+package arrow
+
+import (
+	"gitee.com/jn-qq/pandas/series"
+	"strings"
+)
+
+// 文件格式的首尾标识
+const magic = "ARROW1"
+
+// 元数据版本
+const (
+	metadataV4 int16 = 3
+	metadataV5 int16 = 4
+)
+
+// 消息类型
+const (
+	headerSchema          uint8 = 1
+	headerDictionaryBatch uint8 = 2
+	headerRecordBatch     uint8 = 3
+)
+
+// 数据类型
+const (
+	typeNull            uint8 = 1
+	typeInt             uint8 = 2
+	typeFloatingPoint   uint8 = 3
+	typeBinary          uint8 = 4
+	typeUtf8            uint8 = 5
+	typeBool            uint8 = 6
+	typeDecimal         uint8 = 7
+	typeDate            uint8 = 8
+	typeTime            uint8 = 9
+	typeTimestamp       uint8 = 10
+	typeInterval        uint8 = 11
+	typeList            uint8 = 12
+	typeStruct          uint8 = 13
+	typeUnion           uint8 = 14
+	typeFixedSizeBinary uint8 = 15
+	typeFixedSizeList   uint8 = 16
+	typeMap             uint8 = 17
+	typeDuration        uint8 = 18
+	typeLargeBinary     uint8 = 19
+	typeLargeUtf8       uint8 = 20
+	typeLargeList       uint8 = 21
+	typeRunEndEncoded   uint8 = 22
+	typeBinaryView      uint8 = 23
+	typeUtf8View        uint8 = 24
+	typeListView        uint8 = 25
+	typeLargeListView   uint8 = 26
+)
+
+// 浮点精度
+const (
+	precisionHalf   int16 = 0
+	precisionSingle int16 = 1
+	precisionDouble int16 = 2
+)
+
+// 时间单位
+const (
+	unitSecond int16 = 0
+	unitMilli  int16 = 1
+	unitMicro  int16 = 2
+	unitNano   int16 = 3
+)
+
+// Kind 列值的类型
+type Kind int
+
+const (
+	KindString Kind = iota // string
+	KindInt                // int
+	KindFloat              // float64
+	KindBool               // bool
+)
+
+func (k Kind) String() string {
+	switch k {
+	case KindInt:
+		return "int"
+	case KindFloat:
+		return "float64"
+	case KindBool:
+		return "bool"
+	default:
+		return "string"
+	}
+}
+
+// 模式中的字段
+type field struct {
+	name      string
+	typ       uint8
+	bitWidth  int   // Int、Time、Decimal 的位宽
+	signed    bool  // Int 是否有符号
+	precision int16 // FloatingPoint 的精度
+	unit      int16 // Date、Time、Timestamp、Duration 的单位
+	scale     int32 // Decimal 的小数位数
+	digits    int32 // Decimal 的有效位数
+	size      int   // FixedSizeBinary 的字节数、FixedSizeList 的元素个数
+	dense     bool  // Union 是否为稠密模式
+	children  []*field
+	dictID    int64
+	index     *field // 字典编码的索引类型，未编码时为 nil
+}
+
+// Column 可读取的列，结构体的子字段展开为单独的列
+type Column struct {
+	Name string // 以 "." 连接的路径，如 user.id
+	List bool   // 是否为列表列
+	Kind Kind   // 值类型，列表列为元素类型
+	Err  error  // 不支持的列（如 Map、结构体列表）读取时返回的错误
+
+	path []*field // 由顶层字段至该列字段的路径，前面的字段均为结构体
+}
+
+// 解析字段，depth 限制嵌套层数
+func parseField(t fbTable, depth int) *field {
+	if depth > 64 {
+		fail(errCorrupt)
+	}
+	f := &field{name: t.string(0), typ: t.uint8(2, 0)}
+	if typ, ok := t.table(3); ok {
+		switch f.typ {
+		case typeInt:
+			f.bitWidth, f.signed = int(typ.int32(0, 0)), typ.bool(1)
+		case typeFloatingPoint:
+			f.precision = typ.int16(0, precisionHalf)
+		case typeDecimal:
+			f.digits, f.scale, f.bitWidth = typ.int32(0, 0), typ.int32(1, 0), int(typ.int32(2, 128))
+		case typeDate:
+			f.unit = typ.int16(0, unitMilli)
+		case typeTime:
+			f.unit, f.bitWidth = typ.int16(0, unitMilli), int(typ.int32(1, 32))
+		case typeTimestamp, typeDuration:
+			f.unit = typ.int16(0, unitSecond)
+		case typeFixedSizeBinary:
+			f.size = int(typ.int32(0, 0))
+		case typeFixedSizeList:
+			f.size = int(typ.int32(0, 0))
+		case typeUnion:
+			f.dense = typ.int16(0, 0) == 1
+		}
+	}
+	if dict, ok := t.table(4); ok {
+		f.dictID = dict.int64(0, 0)
+		f.index = &field{name: f.name, typ: typeInt, bitWidth: 32, signed: true}
+		if index, ok := dict.table(1); ok {
+			f.index.bitWidth, f.index.signed = int(index.int32(0, 0)), index.bool(1)
+		}
+	}
+	for _, child := range t.tables(5) {
+		f.children = append(f.children, parseField(child, depth+1))
+	}
+	if f.size < 0 || f.scale < -maxScale || f.scale > maxScale {
+		fail(errCorrupt)
+	}
+	return f
+}
+
+// 解析模式中的字段
+func parseSchema(t fbTable) (fields []*field, err error) {
+	defer catch(&err)
+	if t.int16(0, 0) != 0 {
+		return nil, series.NewError(series.ErrUnsupportedOperation, MsgBigEndian)
+	}
+	for _, f := range t.tables(1) {
+		fields = append(fields, parseField(f, 0))
+	}
+	return fields, nil
+}
+
+// 由模式中的字段生成可读取的列
+func newColumns(fields []*field) []*Column {
+	var columns []*Column
+	var walk func(path []*field, names []string)
+	walk = func(path []*field, names []string) {
+		f := path[len(path)-1]
+		if f.typ == typeStruct && f.index == nil {
+			for _, child := range f.children {
+				walk(append(path[:len(path):len(path)], child), append(names[:len(names):len(names)], child.name))
+			}
+			return
+		}
+		c := &Column{Name: strings.Join(names, "."), path: path}
+		c.Kind, c.List, c.Err = kindOf(f)
+		if c.Err != nil {
+			c.Err = columnError(c.Err, c.Name)
+		}
+		columns = append(columns, c)
+	}
+	for _, f := range fields {
+		walk([]*field{f}, []string{f.name})
+	}
+	return columns
+}
+
+// 返回字段的值类型及是否为列表
+func kindOf(f *field) (Kind, bool, error) {
+	switch f.typ {
+	case typeList, typeLargeList, typeFixedSizeList:
+		if len(f.children) != 1 {
+			return KindString, false, series.NewError(series.ErrCorruptData, MsgListChildren)
+		}
+		kind, list, err := kindOf(f.children[0])
+		if err == nil && list {
+			err = series.NewError(series.ErrUnsupportedOperation, MsgNestedList)
+		}
+		return kind, true, err
+	}
+	kind, ok := elemKind(f)
+	if !ok {
+		return kind, false, series.NewError(series.ErrUnsupportedOperation, MsgType, f.typ)
+	}
+	return kind, false, nil
+}
+
+// 返回基本类型字段的值类型
+func elemKind(f *field) (Kind, bool) {
+	switch f.typ {
+	case typeInt, typeDuration:
+		return KindInt, true
+	case typeFloatingPoint:
+		return KindFloat, true
+	case typeDecimal:
+		if floatDecimal(f) {
+			return KindFloat, true
+		}
+		return KindString, true
+	case typeBool:
+		return KindBool, true
+	case typeNull, typeBinary, typeUtf8, typeLargeBinary, typeLargeUtf8, typeBinaryView, typeUtf8View,
+		typeFixedSizeBinary, typeDate, typeTime, typeTimestamp:
+		return KindString, true
+	}
+	return KindString, false
+}
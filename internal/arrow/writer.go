@@ -0,0 +1,319 @@
+/**
+  Copyright (c) [2024] [JiangNan]
+  [pandas] is licensed under Mulan PSL v2.
+  You can use this software according to the terms and conditions of the Mulan PSL v2.
+  You may obtain a copy of Mulan PSL v2 at:
+           http://license.coscl.org.cn/MulanPSL2
+  THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND, EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT, MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+  See the Mulan PSL v2 for more details.
+*/
+
+package arrow
+
+import (
+	"encoding/binary"
+	"fmt"
+	"gitee.com/jn-qq/pandas/series"
+	"io"
+	"math"
+)
+
+// Field 写入的列
+type Field struct {
+	Name string
+	Kind Kind // 值类型，列表列为元素类型
+	List bool // 是否为列表列
+}
+
+// Compressor 压缩缓冲区，codec 为 "lz4"（LZ4 帧格式）或 "zstd"
+type Compressor func(codec string, data []byte) ([]byte, error)
+
+// Writer Arrow IPC 写入器，各列均写为可空列：int 为 Int64，float64 为 Float64，string 为 Utf8，bool 为 Bool，
+// 列表列为元素字段名为 item 的 List
+type Writer struct {
+	w        io.Writer
+	fields   []Field
+	file     bool
+	codec    string
+	compress Compressor
+	offset   int64
+	schema   fbObject
+	blocks   []byte // 记录批的 Block 结构体数据
+}
+
+// NewStreamWriter 创建流格式写入器并写入模式，codec 为 "lz4" 或 "zstd" 时由 compress 压缩各缓冲区，为空时不压缩
+func NewStreamWriter(w io.Writer, fields []Field, codec string, compress Compressor) (*Writer, error) {
+	return newWriter(w, fields, false, codec, compress)
+}
+
+// NewFileWriter 创建文件格式写入器并写入文件头及模式
+func NewFileWriter(w io.Writer, fields []Field, codec string, compress Compressor) (*Writer, error) {
+	return newWriter(w, fields, true, codec, compress)
+}
+
+func newWriter(w io.Writer, fields []Field, file bool, codec string, compress Compressor) (*Writer, error) {
+	if codec != "" && (codec != "lz4" && codec != "zstd" || compress == nil) {
+		return nil, series.NewError(series.ErrInvalidArgument, MsgCodec, codec)
+	}
+	aw := &Writer{w: w, fields: fields, file: file, codec: codec, compress: compress}
+	schemaFields := make([]fbObject, 0, len(fields))
+	for _, f := range fields {
+		item := fbObject{f.Name, true, kindType(f.Kind), kindObject(f.Kind), nil, []fbObject{}}
+		if f.List {
+			item = fbObject{f.Name, true, typeList, fbObject{}, nil, []fbObject{
+				{"item", true, kindType(f.Kind), kindObject(f.Kind), nil, []fbObject{}},
+			}}
+		}
+		schemaFields = append(schemaFields, item)
+	}
+	aw.schema = fbObject{nil, schemaFields}
+	if file {
+		if err := aw.write([]byte(magic + "\x00\x00")); err != nil {
+			return nil, err
+		}
+	}
+	if _, err := aw.writeMessage(headerSchema, aw.schema, nil); err != nil {
+		return nil, err
+	}
+	return aw, nil
+}
+
+func kindType(kind Kind) uint8 {
+	switch kind {
+	case KindInt:
+		return typeInt
+	case KindFloat:
+		return typeFloatingPoint
+	case KindBool:
+		return typeBool
+	default:
+		return typeUtf8
+	}
+}
+
+func kindObject(kind Kind) fbObject {
+	switch kind {
+	case KindInt:
+		return fbObject{int32(64), true}
+	case KindFloat:
+		return fbObject{precisionDouble}
+	default:
+		return fbObject{}
+	}
+}
+
+func (w *Writer) write(b []byte) error {
+	n, err := w.w.Write(b)
+	w.offset += int64(n)
+	return err
+}
+
+// 写入一条消息，返回元数据部分的长度
+func (w *Writer) writeMessage(kind uint8, header fbObject, body []byte) (int, error) {
+	meta := fbEncode(fbObject{metadataV5, kind, header, int64(len(body))})
+	for (8+len(meta))%8 != 0 {
+		meta = append(meta, 0)
+	}
+	prefix := binary.LittleEndian.AppendUint32(nil, continuation)
+	prefix = binary.LittleEndian.AppendUint32(prefix, uint32(len(meta)))
+	for _, b := range [][]byte{prefix, meta, body} {
+		if err := w.write(b); err != nil {
+			return 0, err
+		}
+	}
+	return len(prefix) + len(meta), nil
+}
+
+// WriteBatch 写入一个记录批，columns 与 fields 一一对应，每行一个值：空值为 nil，
+// 基本类型列为 int、float64、string 或 bool，列表列为 []any
+func (w *Writer) WriteBatch(columns [][]any) error {
+	if len(columns) != len(w.fields) {
+		return &series.LengthMismatchError{Want: len(w.fields), Got: len(columns)}
+	}
+	rows := 0
+	if len(columns) > 0 {
+		rows = len(columns[0])
+	}
+	b := &batchBuilder{codec: w.codec, compress: w.compress}
+	for i, column := range columns {
+		if len(column) != rows {
+			return columnError(&series.LengthMismatchError{Want: rows, Got: len(column)}, w.fields[i].Name)
+		}
+		if err := b.column(w.fields[i], column); err != nil {
+			return columnError(err, w.fields[i].Name)
+		}
+	}
+	batch := fbObject{int64(rows), fbStructs{16, b.nodes}, fbStructs{16, b.buffers}}
+	if w.codec != "" {
+		codec := uint8(0)
+		if w.codec == "zstd" {
+			codec = 1
+		}
+		batch = append(batch, fbObject{codec, uint8(0)})
+	}
+	offset := w.offset
+	metaSize, err := w.writeMessage(headerRecordBatch, batch, b.body)
+	if err != nil {
+		return err
+	}
+	w.blocks = binary.LittleEndian.AppendUint64(w.blocks, uint64(offset))
+	w.blocks = binary.LittleEndian.AppendUint64(w.blocks, uint64(metaSize))
+	w.blocks = binary.LittleEndian.AppendUint64(w.blocks, uint64(len(b.body)))
+	return nil
+}
+
+// Close 写入流结束标识，文件格式另写入文件尾部
+func (w *Writer) Close() error {
+	eos := binary.LittleEndian.AppendUint32(nil, continuation)
+	if err := w.write(append(eos, 0, 0, 0, 0)); err != nil {
+		return err
+	}
+	if !w.file {
+		return nil
+	}
+	footer := fbEncode(fbObject{metadataV5, w.schema, fbStructs{24, nil}, fbStructs{24, w.blocks}})
+	footer = binary.LittleEndian.AppendUint32(footer, uint32(len(footer)))
+	return w.write(append(footer, magic...))
+}
+
+// 记录批的 FieldNode、Buffer 及消息体
+type batchBuilder struct {
+	nodes    []byte
+	buffers  []byte
+	body     []byte
+	codec    string
+	compress Compressor
+}
+
+func (b *batchBuilder) node(length, nulls int) {
+	b.nodes = binary.LittleEndian.AppendUint64(b.nodes, uint64(length))
+	b.nodes = binary.LittleEndian.AppendUint64(b.nodes, uint64(nulls))
+}
+
+// 追加缓冲区，压缩时以 8 字节的解压后长度开头，各缓冲区按 8 字节对齐
+func (b *batchBuilder) buffer(data []byte) error {
+	if b.codec != "" && len(data) > 0 {
+		compressed, err := b.compress(b.codec, data)
+		if err != nil {
+			return err
+		}
+		data = append(binary.LittleEndian.AppendUint64(nil, uint64(len(data))), compressed...)
+	}
+	b.buffers = binary.LittleEndian.AppendUint64(b.buffers, uint64(len(b.body)))
+	b.buffers = binary.LittleEndian.AppendUint64(b.buffers, uint64(len(data)))
+	b.body = append(b.body, data...)
+	for len(b.body)%8 != 0 {
+		b.body = append(b.body, 0)
+	}
+	return nil
+}
+
+// 追加有效位图，没有空值时为空缓冲区
+func (b *batchBuilder) validity(values []any) error {
+	nulls := 0
+	bitmap := make([]byte, (len(values)+7)/8)
+	for i, value := range values {
+		if value == nil {
+			nulls++
+		} else {
+			bitmap[i/8] |= 1 << (i % 8)
+		}
+	}
+	b.node(len(values), nulls)
+	if nulls == 0 {
+		bitmap = nil
+	}
+	return b.buffer(bitmap)
+}
+
+func (b *batchBuilder) column(f Field, values []any) error {
+	if err := b.validity(values); err != nil {
+		return err
+	}
+	if !f.List {
+		return b.values(f.Kind, values)
+	}
+	offsets := binary.LittleEndian.AppendUint32(nil, 0)
+	var items []any
+	for _, value := range values {
+		if value != nil {
+			list, ok := value.([]any)
+			if !ok {
+				return &series.TypeMismatchError{Want: "[]any", Got: fmt.Sprintf("%T", value)}
+			}
+			items = append(items, list...)
+		}
+		if len(items) > math.MaxInt32 {
+			return series.NewError(series.ErrInvalidArgument, MsgTooLarge)
+		}
+		offsets = binary.LittleEndian.AppendUint32(offsets, uint32(len(items)))
+	}
+	if err := b.buffer(offsets); err != nil {
+		return err
+	}
+	if err := b.validity(items); err != nil {
+		return err
+	}
+	return b.values(f.Kind, items)
+}
+
+// 追加基本类型值的数据缓冲区，空值处为零值
+func (b *batchBuilder) values(kind Kind, values []any) error {
+	var data []byte
+	switch kind {
+	case KindBool:
+		data = make([]byte, (len(values)+7)/8)
+		for i, value := range values {
+			if value == nil {
+				continue
+			}
+			v, ok := value.(bool)
+			if !ok {
+				return &series.TypeMismatchError{Want: "bool", Got: fmt.Sprintf("%T", value)}
+			}
+			if v {
+				data[i/8] |= 1 << (i % 8)
+			}
+		}
+	case KindString:
+		offsets := binary.LittleEndian.AppendUint32(make([]byte, 0, 4*len(values)+4), 0)
+		for _, value := range values {
+			if value != nil {
+				v, ok := value.(string)
+				if !ok {
+					return &series.TypeMismatchError{Want: "string", Got: fmt.Sprintf("%T", value)}
+				}
+				data = append(data, v...)
+			}
+			if len(data) > math.MaxInt32 {
+				return series.NewError(series.ErrInvalidArgument, MsgTooLarge)
+			}
+			offsets = binary.LittleEndian.AppendUint32(offsets, uint32(len(data)))
+		}
+		if err := b.buffer(offsets); err != nil {
+			return err
+		}
+	default:
+		data = make([]byte, 0, 8*len(values))
+		for _, value := range values {
+			var bits uint64
+			switch v := value.(type) {
+			case nil:
+			case int:
+				if kind != KindInt {
+					return &series.TypeMismatchError{Want: "float64", Got: "int"}
+				}
+				bits = uint64(v)
+			case float64:
+				if kind != KindFloat {
+					return &series.TypeMismatchError{Want: "int", Got: "float64"}
+				}
+				bits = math.Float64bits(v)
+			default:
+				return &series.TypeMismatchError{Want: kind.String(), Got: fmt.Sprintf("%T", value)}
+			}
+			data = binary.LittleEndian.AppendUint64(data, bits)
+		}
+	}
+	return b.buffer(data)
+}
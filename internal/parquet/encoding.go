@@ -0,0 +1,341 @@
+/**
+  Copyright (c) [2024] [JiangNan]
+  [pandas] is licensed under Mulan PSL v2.
+  You can use this software according to the terms and conditions of the Mulan PSL v2.
+  You may obtain a copy of Mulan PSL v2 at:
+           http://license.coscl.org.cn/MulanPSL2
+  THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND, EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT, MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+  See the Mulan PSL v2 for more details.
+*/
+
+package parquet
+
+import (
+	"encoding/binary"
+	"math"
+	"math/bits"
+)
+
+// 表示 0 至 max 的整数所需的位数
+func bitWidth(max int) int {
+	return bits.Len(uint(max))
+}
+
+// 从 data 中以低位优先读取 count 个位宽为 width 的整数
+func unpack(data []byte, width, count int, dst []uint64) ([]uint64, error) {
+	if width > 64 {
+		return nil, errCorrupt
+	}
+	if width == 0 {
+		for i := 0; i < count; i++ {
+			dst = append(dst, 0)
+		}
+		return dst, nil
+	}
+	if (count*width+7)/8 > len(data) {
+		return nil, errCorrupt
+	}
+	pos := 0
+	for i := 0; i < count; i++ {
+		var v uint64
+		for got := 0; got < width; {
+			b := uint64(data[pos/8] >> (pos % 8))
+			n := min(8-pos%8, width-got)
+			v |= (b & (1<<n - 1)) << got
+			got += n
+			pos += n
+		}
+		dst = append(dst, v)
+	}
+	return dst, nil
+}
+
+// 解码 RLE/bit-packed 混合编码的 count 个整数，返回解码结果及读取的字节数
+func readHybrid(data []byte, width, count int) ([]int32, int, error) {
+	if count < 0 {
+		return nil, 0, errCorrupt
+	}
+	// RLE 段可表示任意多个值，按实际解码的个数扩容
+	values := make([]int32, 0, min(count, 1<<16))
+	pos := 0
+	byteWidth := (width + 7) / 8
+	var packed []uint64
+	for len(values) < count {
+		header, n := binary.Uvarint(data[pos:])
+		if n <= 0 {
+			return nil, 0, errCorrupt
+		}
+		pos += n
+		if header&1 == 0 {
+			// RLE 段：重复次数及以 byteWidth 字节存储的值
+			run := int(header >> 1)
+			if pos+byteWidth > len(data) {
+				return nil, 0, errCorrupt
+			}
+			var v uint32
+			for i := 0; i < byteWidth; i++ {
+				v |= uint32(data[pos+i]) << (8 * i)
+			}
+			pos += byteWidth
+			for i := 0; i < run && len(values) < count; i++ {
+				values = append(values, int32(v))
+			}
+		} else {
+			// bit-packed 段：每组 8 个值
+			groups := int(header >> 1)
+			size := groups * width
+			if groups > count || pos+size > len(data) {
+				return nil, 0, errCorrupt
+			}
+			var err error
+			if packed, err = unpack(data[pos:pos+size], width, groups*8, packed[:0]); err != nil {
+				return nil, 0, err
+			}
+			pos += size
+			for _, v := range packed {
+				if len(values) == count {
+					break
+				}
+				values = append(values, int32(v))
+			}
+		}
+	}
+	return values, pos, nil
+}
+
+// 以 RLE 段编码整数，连续相同的值合并为一段
+func appendHybrid(buf []byte, values []int32, width int) []byte {
+	byteWidth := (width + 7) / 8
+	for i := 0; i < len(values); {
+		j := i + 1
+		for j < len(values) && values[j] == values[i] {
+			j++
+		}
+		buf = binary.AppendUvarint(buf, uint64(j-i)<<1)
+		for k := 0; k < byteWidth; k++ {
+			buf = append(buf, byte(uint32(values[i])>>(8*k)))
+		}
+		i = j
+	}
+	return buf
+}
+
+// 物理类型的定长字节数，变长类型返回 0
+func fixedSize(physical, typeLength int32) int {
+	switch physical {
+	case Int32, Float:
+		return 4
+	case Int64, Double:
+		return 8
+	case Int96:
+		return 12
+	case FixedLenByteArray:
+		return int(typeLength)
+	}
+	return 0
+}
+
+// 解码 count 个 PLAIN 编码的值：Boolean 为 bool，Int32 为 int32，Int64 为 int64，Int96 为 [12]byte，
+// Float 为 float32，Double 为 float64，ByteArray、FixedLenByteArray 为 []byte
+func decodePlain(data []byte, physical, typeLength int32, count int) ([]any, error) {
+	if count < 0 {
+		return nil, errCorrupt
+	}
+	// 每个字节至多存储 8 个值，损坏的 count 不会导致按其分配内存
+	values := make([]any, 0, min(count, 8*len(data)))
+	switch physical {
+	case Boolean:
+		if (count+7)/8 > len(data) {
+			return nil, errCorrupt
+		}
+		for i := 0; i < count; i++ {
+			values = append(values, data[i/8]>>(i%8)&1 == 1)
+		}
+		return values, nil
+	case ByteArray:
+		pos := 0
+		for i := 0; i < count; i++ {
+			if pos+4 > len(data) {
+				return nil, errCorrupt
+			}
+			n := int(binary.LittleEndian.Uint32(data[pos:]))
+			pos += 4
+			if n < 0 || pos+n > len(data) {
+				return nil, errCorrupt
+			}
+			values = append(values, data[pos:pos+n])
+			pos += n
+		}
+		return values, nil
+	}
+	size := fixedSize(physical, typeLength)
+	if size <= 0 || count*size > len(data) {
+		return nil, errCorrupt
+	}
+	for i := 0; i < count; i++ {
+		values = append(values, fixedValue(data[i*size:(i+1)*size], physical))
+	}
+	return values, nil
+}
+
+// 解码一个定长值
+func fixedValue(b []byte, physical int32) any {
+	switch physical {
+	case Int32:
+		return int32(binary.LittleEndian.Uint32(b))
+	case Int64:
+		return int64(binary.LittleEndian.Uint64(b))
+	case Int96:
+		var v [12]byte
+		copy(v[:], b)
+		return v
+	case Float:
+		return math.Float32frombits(binary.LittleEndian.Uint32(b))
+	case Double:
+		return math.Float64frombits(binary.LittleEndian.Uint64(b))
+	default:
+		return b
+	}
+}
+
+// 解码 BYTE_STREAM_SPLIT 编码的 count 个定长值
+func decodeByteStreamSplit(data []byte, physical, typeLength int32, count int) ([]any, error) {
+	size := fixedSize(physical, typeLength)
+	if size <= 0 || physical == Int96 || count*size > len(data) {
+		return nil, errCorrupt
+	}
+	values := make([]any, 0, count)
+	b := make([]byte, size)
+	for i := 0; i < count; i++ {
+		for j := 0; j < size; j++ {
+			b[j] = data[j*count+i]
+		}
+		values = append(values, fixedValue(b, physical))
+		b = make([]byte, size)
+	}
+	return values, nil
+}
+
+// 解码 DELTA_BINARY_PACKED 编码的整数，返回解码结果及读取的字节数
+func decodeDeltaBinaryPacked(data []byte) ([]int64, int, error) {
+	pos := 0
+	uvarint := func() (uint64, error) {
+		v, n := binary.Uvarint(data[pos:])
+		if n <= 0 {
+			return 0, errCorrupt
+		}
+		pos += n
+		return v, nil
+	}
+	varint := func() (int64, error) {
+		v, n := binary.Varint(data[pos:])
+		if n <= 0 {
+			return 0, errCorrupt
+		}
+		pos += n
+		return v, nil
+	}
+	blockSize, err := uvarint()
+	if err != nil {
+		return nil, 0, err
+	}
+	miniBlocks, err := uvarint()
+	if err != nil {
+		return nil, 0, err
+	}
+	total, err := uvarint()
+	if err != nil {
+		return nil, 0, err
+	}
+	first, err := varint()
+	if err != nil {
+		return nil, 0, err
+	}
+	if miniBlocks == 0 || blockSize%miniBlocks != 0 || total > math.MaxInt32 || blockSize > math.MaxInt32 {
+		return nil, 0, errCorrupt
+	}
+	perMini := int(blockSize / miniBlocks)
+	values := make([]int64, 0, min(total, 1<<20))
+	if total > 0 {
+		values = append(values, first)
+	}
+	last := first
+	var deltas []uint64
+	for uint64(len(values)) < total {
+		minDelta, err := varint()
+		if err != nil {
+			return nil, 0, err
+		}
+		if pos+int(miniBlocks) > len(data) {
+			return nil, 0, errCorrupt
+		}
+		widths := data[pos : pos+int(miniBlocks)]
+		pos += int(miniBlocks)
+		for _, width := range widths {
+			if uint64(len(values)) >= total {
+				break
+			}
+			size := perMini * int(width) / 8
+			if pos+size > len(data) {
+				return nil, 0, errCorrupt
+			}
+			if deltas, err = unpack(data[pos:pos+size], int(width), perMini, deltas[:0]); err != nil {
+				return nil, 0, err
+			}
+			pos += size
+			for _, d := range deltas {
+				if uint64(len(values)) >= total {
+					break
+				}
+				last += minDelta + int64(d)
+				values = append(values, last)
+			}
+		}
+	}
+	return values, pos, nil
+}
+
+// 解码 DELTA_LENGTH_BYTE_ARRAY 编码的 count 个字节数组
+func decodeDeltaLengthByteArray(data []byte, count int) ([]any, error) {
+	lengths, pos, err := decodeDeltaBinaryPacked(data)
+	if err != nil {
+		return nil, err
+	}
+	if len(lengths) < count {
+		return nil, errCorrupt
+	}
+	values := make([]any, 0, count)
+	for _, n := range lengths[:count] {
+		if n < 0 || int64(pos)+n > int64(len(data)) {
+			return nil, errCorrupt
+		}
+		values = append(values, data[pos:pos+int(n)])
+		pos += int(n)
+	}
+	return values, nil
+}
+
+// 解码 DELTA_BYTE_ARRAY 编码的 count 个字节数组，每个值由前一个值的前缀及后缀组成
+func decodeDeltaByteArray(data []byte, count int) ([]any, error) {
+	prefixes, pos, err := decodeDeltaBinaryPacked(data)
+	if err != nil {
+		return nil, err
+	}
+	suffixes, err := decodeDeltaLengthByteArray(data[pos:], count)
+	if err != nil || len(prefixes) < count {
+		return nil, errCorrupt
+	}
+	values := make([]any, 0, count)
+	var prev []byte
+	for i, suffix := range suffixes {
+		n := prefixes[i]
+		if n < 0 || n > int64(len(prev)) {
+			return nil, errCorrupt
+		}
+		v := make([]byte, 0, int(n)+len(suffix.([]byte)))
+		v = append(append(v, prev[:n]...), suffix.([]byte)...)
+		values = append(values, v)
+		prev = v
+	}
+	return values, nil
+}
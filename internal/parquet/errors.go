@@ -0,0 +1,81 @@
+/**
+  Copyright (c) [2024] [JiangNan]
+  [pandas] is licensed under Mulan PSL v2.
+  You can use this software according to the terms and conditions of the Mulan PSL v2.
+  You may obtain a copy of Mulan PSL v2 at:
+           http://license.coscl.org.cn/MulanPSL2
+  THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND, EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT, MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+  See the Mulan PSL v2 for more details.
+*/
+
+package parquet
+
+import (
+	"gitee.com/jn-qq/pandas/series"
+)
+
+// 消息编号，消息语言由 series.SetLanguage 设置
+const (
+	MsgNotParquet     = "ParquetNotParquet"
+	MsgCorruptPage    = "ParquetCorruptPage"
+	MsgCorruptSnappy  = "ParquetCorruptSnappy"
+	MsgCorruptThrift  = "ParquetCorruptThrift"
+	MsgCorruptGzip    = "ParquetCorruptGzip"
+	MsgFileMetadata   = "ParquetFileMetadata"
+	MsgColumn         = "ParquetColumn"
+	MsgNestedList     = "ParquetNestedList"
+	MsgGroupList      = "ParquetGroupList"
+	MsgPhysicalType   = "ParquetPhysicalType"
+	MsgColumnMetadata = "ParquetColumnMetadata"
+	MsgCodec          = "ParquetCodec"
+	MsgEncoding       = "ParquetEncoding"
+)
+
+func init() {
+	series.RegisterMessages(series.Zh, map[string]string{
+		MsgNotParquet:     "不是 Parquet 文件",
+		MsgCorruptPage:    "页数据格式错误",
+		MsgCorruptSnappy:  "Snappy 数据块格式错误",
+		MsgCorruptThrift:  "Thrift 数据格式错误",
+		MsgCorruptGzip:    "Gzip 数据格式错误",
+		MsgFileMetadata:   "文件元数据",
+		MsgColumn:         "Parquet 列 %s",
+		MsgNestedList:     "不支持嵌套列表",
+		MsgGroupList:      "不支持 Map 及结构体列表",
+		MsgPhysicalType:   "未知的物理类型 %d",
+		MsgColumnMetadata: "缺少列元数据",
+		MsgCodec:          "不支持的压缩格式 %s",
+		MsgEncoding:       "不支持的编码 %d",
+	})
+	series.RegisterMessages(series.En, map[string]string{
+		MsgNotParquet:     "not a parquet file",
+		MsgCorruptPage:    "invalid page data",
+		MsgCorruptSnappy:  "invalid snappy block",
+		MsgCorruptThrift:  "invalid thrift data",
+		MsgCorruptGzip:    "invalid gzip data",
+		MsgFileMetadata:   "file metadata",
+		MsgColumn:         "parquet column %s",
+		MsgNestedList:     "nested lists are not supported",
+		MsgGroupList:      "maps and lists of groups are not supported",
+		MsgPhysicalType:   "unknown physical type %d",
+		MsgColumnMetadata: "missing column metadata",
+		MsgCodec:          "unsupported compression codec %s",
+		MsgEncoding:       "unsupported encoding %d",
+	})
+}
+
+// 错误类型，文件损坏的错误均可通过 errors.Is(err, series.ErrCorruptData) 判断，
+// 不支持的列及编码可通过 errors.Is(err, series.ErrUnsupportedOperation) 判断
+var (
+	// ErrFormat 不是合法的 Parquet 文件
+	ErrFormat  = series.NewError(series.ErrCorruptData, MsgNotParquet)
+	errCorrupt = series.NewError(series.ErrCorruptData, MsgCorruptPage)
+	errSnappy  = series.NewError(series.ErrCorruptData, MsgCorruptSnappy)
+	errThrift  = series.NewError(series.ErrCorruptData, MsgCorruptThrift)
+	errGzip    = series.NewError(series.ErrCorruptData, MsgCorruptGzip)
+)
+
+// 为错误附加列名
+func columnError(err error, name string) error {
+	return series.NewError(err, MsgColumn, name)
+}
@@ -0,0 +1,249 @@
+/**
+  Copyright (c) [2024] [JiangNan]
+  [pandas] is licensed under Mulan PSL v2.
+  You can use this software according to the terms and conditions of the Mulan PSL v2.
+  You may obtain a copy of Mulan PSL v2 at:
+           http://license.coscl.org.cn/MulanPSL2
+  THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND, EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT, MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+  See the Mulan PSL v2 for more details.
+*/
+
+// Package parquet 实现 Apache Parquet 文件格式中 dataframe 所需的部分：
+// 扁平及嵌套结构中的基本类型列与列表列的读取，以及基本类型列与列表列的写入
+package parquet
+
+// 物理类型
+const (
+	Boolean           int32 = 0
+	Int32             int32 = 1
+	Int64             int32 = 2
+	Int96             int32 = 3
+	Float             int32 = 4
+	Double            int32 = 5
+	ByteArray         int32 = 6
+	FixedLenByteArray int32 = 7
+)
+
+// 重复类型
+const (
+	required int32 = 0
+	optional int32 = 1
+	repeated int32 = 2
+)
+
+// 转换类型（旧版逻辑类型）
+const (
+	convUTF8            int32 = 0
+	convMap             int32 = 1
+	convMapKeyValue     int32 = 2
+	convList            int32 = 3
+	convEnum            int32 = 4
+	convDecimal         int32 = 5
+	convDate            int32 = 6
+	convTimeMillis      int32 = 7
+	convTimeMicros      int32 = 8
+	convTimestampMillis int32 = 9
+	convTimestampMicros int32 = 10
+	convJSON            int32 = 19
+)
+
+// 页类型
+const (
+	dataPage       int32 = 0
+	dictionaryPage int32 = 2
+	dataPageV2     int32 = 3
+)
+
+// 编码
+const (
+	encPlain                int32 = 0
+	encPlainDictionary      int32 = 2
+	encRLE                  int32 = 3
+	encBitPacked            int32 = 4
+	encDeltaBinaryPacked    int32 = 5
+	encDeltaLengthByteArray int32 = 6
+	encDeltaByteArray       int32 = 7
+	encRLEDictionary        int32 = 8
+	encByteStreamSplit      int32 = 9
+)
+
+// Codec 页压缩格式
+type Codec int32
+
+const (
+	Uncompressed Codec = 0
+	Snappy       Codec = 1
+	Gzip         Codec = 2
+	LZO          Codec = 3
+	Brotli       Codec = 4
+	LZ4          Codec = 5
+	Zstd         Codec = 6
+	LZ4Raw       Codec = 7
+)
+
+var codecNames = map[Codec]string{
+	Uncompressed: "uncompressed",
+	Snappy:       "snappy",
+	Gzip:         "gzip",
+	LZO:          "lzo",
+	Brotli:       "brotli",
+	LZ4:          "lz4",
+	Zstd:         "zstd",
+	LZ4Raw:       "lz4_raw",
+}
+
+func (c Codec) String() string {
+	if name, ok := codecNames[c]; ok {
+		return name
+	}
+	return "unknown"
+}
+
+// CodecByName 按名称查找压缩格式，如 "snappy"、"zstd"
+func CodecByName(name string) (Codec, bool) {
+	for c, n := range codecNames {
+		if n == name {
+			return c, true
+		}
+	}
+	return 0, false
+}
+
+// Logical 逻辑类型，由转换类型及逻辑类型注解合并而来
+type Logical int
+
+const (
+	LogicalNone Logical = iota
+	LogicalString
+	LogicalDate
+	LogicalTime
+	LogicalTimestamp
+	LogicalDecimal
+	LogicalUUID
+)
+
+// 时间单位
+const (
+	unitMillis = 1
+	unitMicros = 2
+	unitNanos  = 3
+)
+
+// 模式树节点
+type node struct {
+	name       string
+	physical   int32
+	typeLength int32
+	repetition int32
+	converted  int32 // 无转换类型时为 -1
+	logical    Logical
+	unit       int   // LogicalTime、LogicalTimestamp 的时间单位
+	scale      int32 // LogicalDecimal 的小数位数
+	precision  int32 // LogicalDecimal 的有效位数
+	children   []*node
+}
+
+func (n *node) isLeaf() bool {
+	return n.children == nil
+}
+
+func (n *node) isList() bool {
+	return n.converted == convList
+}
+
+// 由扁平的 SchemaElement 列表构建模式树
+func buildSchema(elements []any) (*node, error) {
+	pos := 0
+	var build func(depth int) (*node, error)
+	build = func(depth int) (*node, error) {
+		if pos >= len(elements) || depth > maxDepth {
+			return nil, errThrift
+		}
+		e, ok := elements[pos].(tStruct)
+		if !ok {
+			return nil, errThrift
+		}
+		pos++
+		n := &node{
+			name:       e.string(4),
+			physical:   int32(e.int(1)),
+			typeLength: int32(e.int(2)),
+			repetition: int32(e.int(3)),
+			converted:  -1,
+			scale:      int32(e.int(7)),
+			precision:  int32(e.int(8)),
+		}
+		if e.has(6) {
+			n.converted = int32(e.int(6))
+		}
+		switch n.converted {
+		case convUTF8, convEnum, convJSON:
+			n.logical = LogicalString
+		case convDecimal:
+			n.logical = LogicalDecimal
+		case convDate:
+			n.logical = LogicalDate
+		case convTimeMillis:
+			n.logical, n.unit = LogicalTime, unitMillis
+		case convTimeMicros:
+			n.logical, n.unit = LogicalTime, unitMicros
+		case convTimestampMillis:
+			n.logical, n.unit = LogicalTimestamp, unitMillis
+		case convTimestampMicros:
+			n.logical, n.unit = LogicalTimestamp, unitMicros
+		}
+		if lt := e.strct(10); lt != nil {
+			switch {
+			case lt.has(1), lt.has(4), lt.has(12):
+				n.logical = LogicalString
+			case lt.has(3):
+				n.converted = convList
+			case lt.has(5):
+				n.logical = LogicalDecimal
+				n.scale, n.precision = int32(lt.strct(5).int(1)), int32(lt.strct(5).int(2))
+			case lt.has(6):
+				n.logical = LogicalDate
+			case lt.has(7):
+				n.logical, n.unit = LogicalTime, timeUnit(lt.strct(7).strct(2))
+			case lt.has(8):
+				n.logical, n.unit = LogicalTimestamp, timeUnit(lt.strct(8).strct(2))
+			case lt.has(14):
+				n.logical = LogicalUUID
+			}
+		}
+		if e.has(5) {
+			count := int(e.int(5))
+			if count < 0 {
+				return nil, errThrift
+			}
+			n.children = make([]*node, 0, min(count, len(elements)))
+			for i := 0; i < count; i++ {
+				child, err := build(depth + 1)
+				if err != nil {
+					return nil, err
+				}
+				n.children = append(n.children, child)
+			}
+		}
+		return n, nil
+	}
+	root, err := build(0)
+	if err != nil {
+		return nil, err
+	}
+	if root.children == nil {
+		root.children = []*node{}
+	}
+	return root, nil
+}
+
+func timeUnit(unit tStruct) int {
+	switch {
+	case unit.has(1):
+		return unitMillis
+	case unit.has(2):
+		return unitMicros
+	default:
+		return unitNanos
+	}
+}
@@ -0,0 +1,325 @@
+/**
+  Copyright (c) [2024] [JiangNan]
+  [pandas] is licensed under Mulan PSL v2.
+  You can use this software according to the terms and conditions of the Mulan PSL v2.
+  You may obtain a copy of Mulan PSL v2 at:
+           http://license.coscl.org.cn/MulanPSL2
+  THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND, EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT, MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+  See the Mulan PSL v2 for more details.
+*/
+
+package parquet
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"gitee.com/jn-qq/pandas/series"
+	"math/big"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// 按列读取全部行组
+func readAll(f *File) (map[string][]any, map[string]error) {
+	values, errs := map[string][]any{}, map[string]error{}
+	for _, c := range f.Columns() {
+		for i := 0; i < f.NumRowGroups(); i++ {
+			v, err := f.ReadColumn(i, c)
+			if err != nil {
+				errs[c.Name] = err
+				break
+			}
+			values[c.Name] = append(values[c.Name], v...)
+		}
+	}
+	return values, errs
+}
+
+// testdata 中的文件取自 apache/parquet-testing，由 Impala、parquet-mr 及 pyarrow 写入，见 testdata/README.md
+func TestGolden(t *testing.T) {
+	files, err := filepath.Glob("testdata/*.parquet")
+	if err != nil || len(files) == 0 {
+		t.Fatal("没有测试文件", err)
+	}
+	for _, name := range files {
+		data, err := os.ReadFile(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		f, err := Open(bytes.NewReader(data), int64(len(data)))
+		if err != nil {
+			t.Fatal(name, err)
+		}
+		values, errs := readAll(f)
+		var got strings.Builder
+		for _, c := range f.Columns() {
+			if err := errs[c.Name]; err != nil {
+				if !errors.Is(err, series.ErrUnsupportedOperation) {
+					t.Errorf("%s: %s: %v", name, c.Name, err)
+				}
+				fmt.Fprintf(&got, "%s\tunsupported\n", c.Name)
+				continue
+			}
+			fmt.Fprintf(&got, "%s\t%#v\n", c.Name, values[c.Name])
+		}
+		want, err := os.ReadFile(strings.TrimSuffix(name, ".parquet") + ".golden")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.String() != string(want) {
+			t.Errorf("%s:\n%s\nwant:\n%s", name, got.String(), want)
+		}
+	}
+}
+
+func TestHybrid(t *testing.T) {
+	// 规范中的示例：bit-packed 段，位宽 3，值 0 至 7
+	values, n, err := readHybrid([]byte{0x03, 0x88, 0xc6, 0xfa}, 3, 8)
+	if err != nil || n != 4 || !reflect.DeepEqual(values, []int32{0, 1, 2, 3, 4, 5, 6, 7}) {
+		t.Errorf("bit-packed: %v %d %v", values, n, err)
+	}
+	for _, width := range []int{1, 3, 8, 9, 17} {
+		values := make([]int32, 100)
+		for i := range values {
+			values[i] = int32(i / 7 % (1 << min(width, 5)))
+		}
+		data := appendHybrid(nil, values, width)
+		got, n, err := readHybrid(data, width, len(values))
+		if err != nil || n != len(data) || !reflect.DeepEqual(got, values) {
+			t.Errorf("width=%d: %v %d %v", width, got, n, err)
+		}
+	}
+	if _, _, err := readHybrid([]byte{0x03, 0x88}, 3, 8); !errors.Is(err, series.ErrCorruptData) {
+		t.Errorf("截断：%v", err)
+	}
+}
+
+func TestSnappy(t *testing.T) {
+	inputs := [][]byte{
+		nil,
+		[]byte("a"),
+		[]byte(strings.Repeat("abcd", 1000)),
+		bytes.Repeat([]byte{0}, 70000),
+	}
+	rnd := rand.New(rand.NewSource(1))
+	random := make([]byte, 100000)
+	rnd.Read(random)
+	inputs = append(inputs, random)
+	for _, input := range inputs {
+		got, err := snappyDecode(snappyEncode(input))
+		if err != nil || !bytes.Equal(got, input) {
+			t.Errorf("长度 %d：%v", len(input), err)
+		}
+	}
+	// 字面量 "abcd" 及与自身重叠的复制
+	got, err := snappyDecode([]byte{12, 3 << 2, 'a', 'b', 'c', 'd', 7<<2 | 2, 4, 0})
+	if err != nil || string(got) != "abcdabcdabcd" {
+		t.Errorf("%q %v", got, err)
+	}
+	for _, data := range [][]byte{{5, 0}, {4, 3 << 2, 'a'}, {4, 0, 'a', 3<<2 | 2, 9, 0}} {
+		if _, err := snappyDecode(data); !errors.Is(err, series.ErrCorruptData) {
+			t.Errorf("%v: %v", data, err)
+		}
+	}
+}
+
+func TestThrift(t *testing.T) {
+	records := make([]any, 20)
+	for i := range records {
+		records[i] = []tField{{1, int32(i)}}
+	}
+	var w tWriter
+	w.writeStruct([]tField{
+		{1, int32(-7)},
+		{2, true},
+		{3, "名称"},
+		{20, int64(1) << 40},
+		{21, false},
+		{22, tListOf{tI32, []any{int32(1), int32(2)}}},
+		{23, []tField{{1, int32(5)}}},
+		{24, tListOf{tRecord, records}},
+	})
+	s, err := (&tReader{r: bytes.NewReader(w.buf)}).readStruct(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.int(1) != -7 || !s.bool(2) || s.string(3) != "名称" || s.int(20) != 1<<40 || s.bool(21) || !s.has(21) {
+		t.Errorf("%v", s)
+	}
+	if !reflect.DeepEqual(s.list(22), []any{int64(1), int64(2)}) || s.strct(23).int(1) != 5 || len(s.list(24)) != 20 {
+		t.Errorf("%v", s)
+	}
+	for n := 0; n < len(w.buf); n++ {
+		if _, err := (&tReader{r: bytes.NewReader(w.buf[:n])}).readStruct(0); err == nil {
+			t.Errorf("截断至 %d 字节未返回错误", n)
+		}
+	}
+	// 嵌套过深
+	deep := bytes.Repeat([]byte{0x1c}, maxDepth+2)
+	if _, err := (&tReader{r: bytes.NewReader(deep)}).readStruct(0); !errors.Is(err, series.ErrCorruptData) {
+		t.Errorf("嵌套过深：%v", err)
+	}
+}
+
+func TestWriter(t *testing.T) {
+	fields := []Field{
+		{Name: "i", Kind: KindInt},
+		{Name: "f", Kind: KindFloat},
+		{Name: "s", Kind: KindString},
+		{Name: "b", Kind: KindBool},
+		{Name: "li", Kind: KindInt, List: true},
+		{Name: "ls", Kind: KindString, List: true},
+	}
+	groups := [][][]any{
+		{
+			{1, nil, -3},
+			{1.5, 2.25, nil},
+			{"a", nil, "中文"},
+			{true, nil, false},
+			{[]any{1, nil, 3}, nil, []any{}},
+			{[]any{"x"}, []any{nil, "yy"}, nil},
+		},
+		{{9}, {nil}, {""}, {false}, {[]any{}}, {[]any{""}}},
+	}
+	want := map[string][]any{}
+	for _, group := range groups {
+		for i, column := range group {
+			want[fields[i].Name] = append(want[fields[i].Name], column...)
+		}
+	}
+	for _, codec := range []Codec{Uncompressed, Snappy, Gzip} {
+		var buf bytes.Buffer
+		w, err := NewWriter(&buf, fields, codec, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, group := range groups {
+			if err = w.WriteRowGroup(group); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err = w.Close(); err != nil {
+			t.Fatal(err)
+		}
+		f, err := Open(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+		if err != nil {
+			t.Fatal(codec, err)
+		}
+		if f.NumRows() != 4 || f.NumRowGroups() != 2 || f.RowGroupRows(1) != 1 {
+			t.Errorf("%s: %d 行 %d 个行组", codec, f.NumRows(), f.NumRowGroups())
+		}
+		for i, c := range f.Columns() {
+			if c.Name != fields[i].Name || c.Kind != fields[i].Kind || c.List != fields[i].List {
+				t.Errorf("%s: 列 %d 为 %+v", codec, i, c)
+			}
+		}
+		got, errs := readAll(f)
+		if len(errs) > 0 || !reflect.DeepEqual(got, want) {
+			t.Errorf("%s: %v %v", codec, got, errs)
+		}
+	}
+
+	var buf bytes.Buffer
+	w, _ := NewWriter(&buf, fields[:2], Uncompressed, nil)
+	var mismatch *series.LengthMismatchError
+	if err := w.WriteRowGroup([][]any{{1, 2}, {1.5}}); !errors.As(err, &mismatch) {
+		t.Errorf("长度不一致：%v", err)
+	}
+	if err := w.WriteRowGroup([][]any{{"1"}, {1.5}}); !errors.Is(err, series.ErrTypeMismatch) {
+		t.Errorf("类型不匹配：%v", err)
+	}
+	if _, err := NewWriter(&buf, fields, Zstd, nil); !errors.Is(err, series.ErrInvalidArgument) {
+		t.Errorf("压缩格式：%v", err)
+	}
+}
+
+// 损坏的文件返回错误，不会 panic 或按损坏的长度分配内存
+func TestCorrupt(t *testing.T) {
+	if _, err := Open(strings.NewReader("not parquet data"), 16); !errors.Is(err, ErrFormat) {
+		t.Errorf("非 Parquet 数据：%v", err)
+	}
+	valid := func(err error) bool {
+		return err == nil || errors.Is(err, series.ErrCorruptData) || errors.Is(err, series.ErrUnsupportedOperation)
+	}
+	var seeds [][]byte
+	for _, name := range []string{"testdata/alltypes_plain.snappy.parquet", "testdata/datapage_v2.snappy.parquet"} {
+		data, err := os.ReadFile(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		seeds = append(seeds, data)
+	}
+	read := func(data []byte) error {
+		f, err := Open(bytes.NewReader(data), int64(len(data)))
+		if err != nil {
+			return err
+		}
+		for _, c := range f.Columns() {
+			for i := 0; i < f.NumRowGroups(); i++ {
+				if _, err := f.ReadColumn(i, c); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	}
+	for n := 0; n < len(seeds[0]); n++ {
+		if err := read(seeds[0][:n]); err == nil || !valid(err) {
+			t.Errorf("截断至 %d 字节：%v", n, err)
+		}
+	}
+	rnd := rand.New(rand.NewSource(1))
+	for k := 0; k < 20000; k++ {
+		data := bytes.Clone(seeds[k%len(seeds)])
+		for j := rnd.Intn(4); j >= 0; j-- {
+			data[rnd.Intn(len(data))] = byte(rnd.Intn(256))
+		}
+		if err := read(data); !valid(err) {
+			t.Fatalf("第 %d 次：%v", k, err)
+		}
+	}
+}
+
+func TestDecimalString(t *testing.T) {
+	tests := []struct {
+		x     string
+		scale int32
+		want  string
+	}{
+		{"12345", 2, "123.45"},
+		{"-5", 2, "-0.05"},
+		{"0", 2, "0.00"},
+		{"7", 0, "7"},
+		{"1234567890123456789012345678901234567", 2, "12345678901234567890123456789012345.67"},
+	}
+	for _, test := range tests {
+		x, _ := new(big.Int).SetString(test.x, 10)
+		if got := decimalString(x, test.scale); got != test.want {
+			t.Errorf("decimalString(%s, %d) = %s，期望 %s", test.x, test.scale, got, test.want)
+		}
+	}
+}
+
+// 超出 time.Duration 范围（约 ±292 年）的时间
+func TestUnitTime(t *testing.T) {
+	tests := []struct {
+		v    int64
+		unit int
+		want string
+	}{
+		{-62135596800000, unitMillis, "0001-01-01T00:00:00Z"},
+		{-62135596800000000, unitMicros, "0001-01-01T00:00:00Z"},
+		{253402300799999999, unitMicros, "9999-12-31T23:59:59.999999Z"},
+		{1, unitNanos, "1970-01-01T00:00:00.000000001Z"},
+	}
+	for _, test := range tests {
+		if got := unitTime(test.v, test.unit).Format("2006-01-02T15:04:05.999999999Z07:00"); got != test.want {
+			t.Errorf("unitTime(%d, %d) = %s，期望 %s", test.v, test.unit, got, test.want)
+		}
+	}
+}
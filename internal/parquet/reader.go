@@ -0,0 +1,648 @@
+/**
+  Copyright (c) [2024] [JiangNan]
+  [pandas] is licensed under Mulan PSL v2.
+  You can use this software according to the terms and conditions of the Mulan PSL v2.
+  You may obtain a copy of Mulan PSL v2 at:
+           http://license.coscl.org.cn/MulanPSL2
+  THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND, EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT, MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+  See the Mulan PSL v2 for more details.
+*/
+
+package parquet
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"encoding/hex"
+	"gitee.com/jn-qq/pandas/series"
+	"io"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const magic = "PAR1"
+
+// Kind 列值的类型
+type Kind int
+
+const (
+	KindString Kind = iota // string
+	KindInt                // int
+	KindFloat              // float64
+	KindBool               // bool
+)
+
+func (k Kind) String() string {
+	switch k {
+	case KindInt:
+		return "int"
+	case KindFloat:
+		return "float64"
+	case KindBool:
+		return "bool"
+	default:
+		return "string"
+	}
+}
+
+// Decompressor 解压 Snappy、Gzip 以外压缩格式的页数据，size 为解压后的大小
+type Decompressor func(codec Codec, data []byte, size int) ([]byte, error)
+
+// File 只读的 Parquet 文件
+type File struct {
+	// Decompress 解压 Snappy、Gzip 以外的压缩格式，为 nil 时读取这些格式的列返回错误
+	Decompress Decompressor
+
+	r         io.ReaderAt
+	size      int64
+	numRows   int64
+	rowGroups []tStruct
+	columns   []*Column
+}
+
+// Column 文件中可读取的列，对应一个基本类型叶子节点
+type Column struct {
+	Name string // 以 "." 连接的路径，列表列为列表节点的路径，如 user.tags
+	List bool   // 是否为列表列
+	Kind Kind   // 值类型，列表列为元素类型
+	Err  error  // 不支持的列（如 Map、结构体列表）读取时返回的错误
+
+	leaf       *node
+	index      int // 在行组中的列序号
+	maxDef     int
+	maxRep     int
+	defBefore  int // 列表节点所在的定义级别，小于时列表为空值，等于时为空列表
+	convert    func(any) any
+	convertErr error
+}
+
+// Open 读取 Parquet 文件元数据，size 为文件大小
+func Open(r io.ReaderAt, size int64) (*File, error) {
+	if size < int64(2*len(magic)+4) {
+		return nil, ErrFormat
+	}
+	var tail [8]byte
+	if _, err := r.ReadAt(tail[:], size-8); err != nil {
+		return nil, err
+	}
+	if string(tail[4:]) != magic {
+		return nil, ErrFormat
+	}
+	metaLen := int64(binary.LittleEndian.Uint32(tail[:4]))
+	if metaLen > size-int64(2*len(magic)+4) {
+		return nil, ErrFormat
+	}
+	meta := make([]byte, metaLen)
+	if _, err := r.ReadAt(meta, size-8-metaLen); err != nil {
+		return nil, err
+	}
+	fm, err := (&tReader{r: bytes.NewReader(meta)}).readStruct(0)
+	if err != nil {
+		// 元数据已完整读入内存，解码失败均为数据损坏
+		return nil, series.NewError(errThrift, MsgFileMetadata)
+	}
+	root, err := buildSchema(fm.list(2))
+	if err != nil {
+		return nil, err
+	}
+	f := &File{r: r, size: size, numRows: fm.int(3)}
+	for _, rg := range fm.list(4) {
+		s, ok := rg.(tStruct)
+		if !ok {
+			return nil, errThrift
+		}
+		f.rowGroups = append(f.rowGroups, s)
+	}
+	var walk func(n *node, path []*node)
+	walk = func(n *node, path []*node) {
+		for _, child := range n.children {
+			p := append(path[:len(path):len(path)], child)
+			if child.isLeaf() {
+				f.columns = append(f.columns, newColumn(p, len(f.columns)))
+			} else {
+				walk(child, p)
+			}
+		}
+	}
+	walk(root, nil)
+	return f, nil
+}
+
+// NumRows 总行数
+func (f *File) NumRows() int64 {
+	return f.numRows
+}
+
+// NumRowGroups 行组数
+func (f *File) NumRowGroups() int {
+	return len(f.rowGroups)
+}
+
+// RowGroupRows 第 i 个行组的行数
+func (f *File) RowGroupRows(i int) int64 {
+	return f.rowGroups[i].int(3)
+}
+
+// Columns 文件中的列，按模式中的顺序
+func (f *File) Columns() []*Column {
+	return f.columns
+}
+
+// 由根节点至叶子节点的路径创建列
+func newColumn(path []*node, index int) *Column {
+	leaf := path[len(path)-1]
+	c := &Column{leaf: leaf, index: index}
+	names := make([]string, 0, len(path))
+	repeatedAt := -1
+	for i, n := range path {
+		switch n.repetition {
+		case optional:
+			c.maxDef++
+		case repeated:
+			if c.maxRep == 0 {
+				repeatedAt = i
+				c.defBefore = c.maxDef
+			}
+			c.maxDef++
+			c.maxRep++
+		}
+		names = append(names, n.name)
+	}
+	c.Name = strings.Join(names, ".")
+	c.Kind, c.convert, c.convertErr = converter(leaf)
+	switch {
+	case c.maxRep > 1:
+		c.Err = columnError(series.NewError(series.ErrUnsupportedOperation, MsgNestedList), c.Name)
+	case c.maxRep == 1:
+		c.List = true
+		// 列表名为带 LIST 注解的父节点，否则为重复节点本身
+		end := repeatedAt + 1
+		if repeatedAt > 0 && path[repeatedAt-1].isList() {
+			end = repeatedAt
+		}
+		c.Name = strings.Join(names[:end], ".")
+		repeatedNode := path[repeatedAt]
+		if !repeatedNode.isLeaf() && (len(repeatedNode.children) != 1 || repeatedAt != len(path)-2) {
+			c.Err = columnError(series.NewError(series.ErrUnsupportedOperation, MsgGroupList), c.Name)
+		}
+	}
+	if c.Err == nil {
+		c.Err = c.convertErr
+	}
+	return c
+}
+
+// 按物理类型及逻辑类型返回值类型及转换函数
+func converter(n *node) (Kind, func(any) any, error) {
+	switch n.physical {
+	case Boolean:
+		return KindBool, func(v any) any { return v }, nil
+	case Int32, Int64:
+		toInt := func(v any) int64 {
+			if i, ok := v.(int32); ok {
+				return int64(i)
+			}
+			return v.(int64)
+		}
+		switch n.logical {
+		case LogicalDate:
+			return KindString, func(v any) any {
+				return time.Unix(toInt(v)*86400, 0).UTC().Format(time.DateOnly)
+			}, nil
+		case LogicalTime:
+			unit := n.unit
+			if n.physical == Int32 {
+				unit = unitMillis
+			}
+			return KindString, func(v any) any {
+				return unitTime(toInt(v), unit).Format("15:04:05.999999999")
+			}, nil
+		case LogicalTimestamp:
+			unit := n.unit
+			return KindString, func(v any) any {
+				return unitTime(toInt(v), unit).Format(time.RFC3339Nano)
+			}, nil
+		case LogicalDecimal:
+			return decimalConverter(n, func(v any) *big.Int { return big.NewInt(toInt(v)) })
+		}
+		return KindInt, func(v any) any { return int(toInt(v)) }, nil
+	case Int96:
+		return KindString, func(v any) any {
+			b := v.([12]byte)
+			nanos := int64(binary.LittleEndian.Uint64(b[:8]))
+			days := int64(binary.LittleEndian.Uint32(b[8:]))
+			// 儒略日 2440588 为 1970-01-01
+			return time.Unix((days-2440588)*86400, nanos).UTC().Format(time.RFC3339Nano)
+		}, nil
+	case Float:
+		return KindFloat, func(v any) any {
+			f, _ := strconv.ParseFloat(strconv.FormatFloat(float64(v.(float32)), 'g', -1, 32), 64)
+			return f
+		}, nil
+	case Double:
+		return KindFloat, func(v any) any { return v.(float64) }, nil
+	case ByteArray, FixedLenByteArray:
+		switch n.logical {
+		case LogicalDecimal:
+			return decimalConverter(n, func(v any) *big.Int {
+				b := v.([]byte)
+				x := new(big.Int).SetBytes(b)
+				if len(b) > 0 && b[0]&0x80 != 0 {
+					x.Sub(x, new(big.Int).Lsh(big.NewInt(1), uint(8*len(b))))
+				}
+				return x
+			})
+		case LogicalUUID:
+			return KindString, func(v any) any {
+				s := hex.EncodeToString(v.([]byte))
+				if len(s) != 32 {
+					return s
+				}
+				return s[:8] + "-" + s[8:12] + "-" + s[12:16] + "-" + s[16:20] + "-" + s[20:]
+			}, nil
+		}
+		return KindString, func(v any) any { return string(v.([]byte)) }, nil
+	}
+	return KindString, nil, columnError(series.NewError(series.ErrCorruptData, MsgPhysicalType, n.physical), n.name)
+}
+
+// 自 1970-01-01 UTC 起 v 个 unit 的时间，不经过 time.Duration，超出约 ±292 年时不会溢出
+func unitTime(v int64, unit int) time.Time {
+	switch unit {
+	case unitMillis:
+		return time.UnixMilli(v).UTC()
+	case unitMicros:
+		return time.UnixMicro(v).UTC()
+	default:
+		return time.Unix(0, v).UTC()
+	}
+}
+
+// float64 可无损还原的十进制有效位数
+const maxFloatDigits = 15
+
+// 小数位数上限，防止损坏的文件导致生成过长的字符串
+const maxScale = 1000
+
+// DECIMAL 的转换函数：有效位数不超过 maxFloatDigits 时为 float64，否则为精确的十进制字符串
+func decimalConverter(n *node, unscaled func(any) *big.Int) (Kind, func(any) any, error) {
+	scale := n.scale
+	if scale < 0 || scale > maxScale {
+		return KindString, nil, columnError(errCorrupt, n.name)
+	}
+	if n.precision > 0 && n.precision <= maxFloatDigits {
+		return KindFloat, func(v any) any {
+			f, _ := strconv.ParseFloat(decimalString(unscaled(v), scale), 64)
+			return f
+		}, nil
+	}
+	return KindString, func(v any) any { return decimalString(unscaled(v), scale) }, nil
+}
+
+// x × 10^-scale 的精确十进制表示
+func decimalString(x *big.Int, scale int32) string {
+	digits, sign := x.String(), ""
+	if digits[0] == '-' {
+		sign, digits = "-", digits[1:]
+	}
+	if scale == 0 {
+		return sign + digits
+	}
+	if n := int(scale) + 1 - len(digits); n > 0 {
+		digits = strings.Repeat("0", n) + digits
+	}
+	point := len(digits) - int(scale)
+	return sign + digits[:point] + "." + digits[point:]
+}
+
+// ReadColumn 读取第 rowGroup 个行组中的列，每行一个值：空值为 nil，
+// 基本类型列为 int、float64、string 或 bool，列表列为 []any
+func (f *File) ReadColumn(rowGroup int, c *Column) ([]any, error) {
+	if c.Err != nil {
+		return nil, c.Err
+	}
+	chunks := f.rowGroups[rowGroup].list(1)
+	if c.index >= len(chunks) {
+		return nil, errThrift
+	}
+	chunk, _ := chunks[c.index].(tStruct)
+	meta := chunk.strct(3)
+	if meta == nil {
+		return nil, columnError(series.NewError(series.ErrCorruptData, MsgColumnMetadata), c.Name)
+	}
+	start := meta.int(9)
+	if dict := meta.int(11); dict > 0 && dict < start {
+		start = dict
+	}
+	size := meta.int(7)
+	if start < 0 || size < 0 || start > f.size {
+		return nil, errThrift
+	}
+	// 列块大小超出文件时读取至文件末尾，页数据不完整时由行数校验报错
+	data := make([]byte, min(size, f.size-start))
+	if _, err := f.r.ReadAt(data, start); err != nil && err != io.EOF {
+		return nil, err
+	}
+	r := &chunkReader{file: f, column: c, codec: Codec(meta.int(4)), data: data}
+	rows, err := r.read(meta.int(5))
+	if err == nil && int64(len(rows)) != f.RowGroupRows(rowGroup) {
+		err = errCorrupt
+	}
+	if err != nil {
+		return nil, columnError(err, c.Name)
+	}
+	return rows, nil
+}
+
+// 列块读取器
+type chunkReader struct {
+	file   *File
+	column *Column
+	codec  Codec
+	data   []byte
+	dict   []any
+	rows   []any
+	list   []any // 正在组装的列表
+	inList bool
+}
+
+func (r *chunkReader) read(numValues int64) ([]any, error) {
+	pos := 0
+	var read int64
+	for read < numValues && pos < len(r.data) {
+		br := bytes.NewReader(r.data[pos:])
+		header, err := (&tReader{r: br}).readStruct(0)
+		if err != nil {
+			return nil, errThrift
+		}
+		pos += len(r.data[pos:]) - br.Len()
+		size := int(header.int(3))
+		if size < 0 || pos+size > len(r.data) {
+			return nil, errCorrupt
+		}
+		page := r.data[pos : pos+size]
+		pos += size
+		uncompressed := int(header.int(2))
+		switch int32(header.int(1)) {
+		case dictionaryPage:
+			if page, err = r.decompress(page, uncompressed); err != nil {
+				return nil, err
+			}
+			dh := header.strct(7)
+			if dh.int(1) > math.MaxInt32 {
+				return nil, errCorrupt
+			}
+			values, err := decodePlain(page, r.column.leaf.physical, r.column.leaf.typeLength, int(dh.int(1)))
+			if err != nil {
+				return nil, err
+			}
+			r.dict = values
+		case dataPage:
+			if page, err = r.decompress(page, uncompressed); err != nil {
+				return nil, err
+			}
+			n, err := r.readPageV1(header.strct(5), page)
+			if err != nil {
+				return nil, err
+			}
+			read += int64(n)
+		case dataPageV2:
+			n, err := r.readPageV2(header.strct(8), page, uncompressed)
+			if err != nil {
+				return nil, err
+			}
+			read += int64(n)
+		}
+	}
+	r.flush()
+	return r.rows, nil
+}
+
+func (r *chunkReader) decompress(data []byte, size int) ([]byte, error) {
+	switch r.codec {
+	case Uncompressed:
+		return data, nil
+	case Snappy:
+		return snappyDecode(data)
+	case Gzip:
+		zr, err := gzip.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return nil, errGzip
+		}
+		out := bytes.NewBuffer(make([]byte, 0, min(max(size, 0), 1<<20)))
+		if _, err = io.Copy(out, zr); err != nil {
+			return nil, errGzip
+		}
+		return out.Bytes(), nil
+	default:
+		if r.file.Decompress == nil {
+			return nil, series.NewError(series.ErrUnsupportedOperation, MsgCodec, r.codec)
+		}
+		return r.file.Decompress(r.codec, data, size)
+	}
+}
+
+func (r *chunkReader) readPageV1(h tStruct, page []byte) (int, error) {
+	count := int(h.int(1))
+	if count < 0 || count > math.MaxInt32 {
+		return 0, errCorrupt
+	}
+	c := r.column
+	var repLevels, defLevels []int32
+	var err error
+	for _, level := range []struct {
+		max    int
+		levels *[]int32
+	}{{c.maxRep, &repLevels}, {c.maxDef, &defLevels}} {
+		if level.max == 0 {
+			continue
+		}
+		if len(page) < 4 {
+			return 0, errCorrupt
+		}
+		n := int(binary.LittleEndian.Uint32(page))
+		if n < 0 || 4+n > len(page) {
+			return 0, errCorrupt
+		}
+		if *level.levels, _, err = readHybrid(page[4:4+n], bitWidth(level.max), count); err != nil {
+			return 0, err
+		}
+		page = page[4+n:]
+	}
+	return count, r.readValues(int32(h.int(2)), page, count, repLevels, defLevels)
+}
+
+func (r *chunkReader) readPageV2(h tStruct, page []byte, uncompressed int) (int, error) {
+	count := int(h.int(1))
+	if count < 0 || count > math.MaxInt32 {
+		return 0, errCorrupt
+	}
+	c := r.column
+	repLen, defLen := int(h.int(6)), int(h.int(5))
+	if repLen < 0 || defLen < 0 || repLen+defLen > len(page) {
+		return 0, errCorrupt
+	}
+	var repLevels, defLevels []int32
+	var err error
+	if c.maxRep > 0 {
+		if repLevels, _, err = readHybrid(page[:repLen], bitWidth(c.maxRep), count); err != nil {
+			return 0, err
+		}
+	}
+	if c.maxDef > 0 {
+		if defLevels, _, err = readHybrid(page[repLen:repLen+defLen], bitWidth(c.maxDef), count); err != nil {
+			return 0, err
+		}
+	}
+	values := page[repLen+defLen:]
+	if !h.has(7) || h.bool(7) {
+		if values, err = r.decompress(values, uncompressed-repLen-defLen); err != nil {
+			return 0, err
+		}
+	}
+	return count, r.readValues(int32(h.int(4)), values, count, repLevels, defLevels)
+}
+
+// 解码页中的值并按重复级别、定义级别组装为行
+func (r *chunkReader) readValues(encoding int32, data []byte, count int, repLevels, defLevels []int32) error {
+	c := r.column
+	present := count
+	if defLevels != nil {
+		present = 0
+		for _, d := range defLevels {
+			if int(d) == c.maxDef {
+				present++
+			}
+		}
+	}
+	values, err := r.decodeValues(encoding, data, present)
+	if err != nil {
+		return err
+	}
+	if len(values) < present {
+		return errCorrupt
+	}
+	next := 0
+	for i := 0; i < count; i++ {
+		def := c.maxDef
+		if defLevels != nil {
+			def = int(defLevels[i])
+		}
+		var value any
+		if def == c.maxDef {
+			if value = values[next]; value == nil {
+				return errCorrupt
+			}
+			value = c.convert(value)
+			next++
+		}
+		if !c.List {
+			r.rows = append(r.rows, value)
+			continue
+		}
+		if repLevels == nil || repLevels[i] == 0 {
+			r.flush()
+			r.inList = true
+			switch {
+			case def < c.defBefore:
+				r.list = nil
+				continue
+			case def == c.defBefore:
+				r.list = []any{}
+				continue
+			}
+			r.list = []any{}
+		}
+		if def > c.defBefore {
+			r.list = append(r.list, value)
+		}
+	}
+	return nil
+}
+
+// 结束正在组装的列表
+func (r *chunkReader) flush() {
+	if r.inList {
+		if r.list == nil {
+			r.rows = append(r.rows, nil)
+		} else {
+			r.rows = append(r.rows, r.list)
+		}
+		r.list, r.inList = nil, false
+	}
+}
+
+// 按编码解码 count 个值
+func (r *chunkReader) decodeValues(encoding int32, data []byte, count int) ([]any, error) {
+	leaf := r.column.leaf
+	switch encoding {
+	case encPlain:
+		return decodePlain(data, leaf.physical, leaf.typeLength, count)
+	case encPlainDictionary, encRLEDictionary:
+		if count == 0 {
+			return nil, nil
+		}
+		if len(data) < 1 {
+			return nil, errCorrupt
+		}
+		indexes, _, err := readHybrid(data[1:], int(data[0]), count)
+		if err != nil {
+			return nil, err
+		}
+		values := make([]any, 0, len(indexes))
+		for _, i := range indexes {
+			if i < 0 || int(i) >= len(r.dict) {
+				return nil, errCorrupt
+			}
+			values = append(values, r.dict[i])
+		}
+		return values, nil
+	case encRLE:
+		if leaf.physical != Boolean || len(data) < 4 {
+			return nil, errCorrupt
+		}
+		levels, _, err := readHybrid(data[4:], 1, count)
+		if err != nil {
+			return nil, err
+		}
+		values := make([]any, 0, len(levels))
+		for _, v := range levels {
+			values = append(values, v == 1)
+		}
+		return values, nil
+	case encDeltaBinaryPacked:
+		if leaf.physical != Int32 && leaf.physical != Int64 {
+			return nil, errCorrupt
+		}
+		ints, _, err := decodeDeltaBinaryPacked(data)
+		if err != nil {
+			return nil, err
+		}
+		values := make([]any, 0, len(ints))
+		for _, v := range ints {
+			if leaf.physical == Int32 {
+				values = append(values, int32(v))
+			} else {
+				values = append(values, v)
+			}
+		}
+		return values, nil
+	case encDeltaLengthByteArray:
+		if leaf.physical != ByteArray {
+			return nil, errCorrupt
+		}
+		return decodeDeltaLengthByteArray(data, count)
+	case encDeltaByteArray:
+		if leaf.physical != ByteArray && leaf.physical != FixedLenByteArray {
+			return nil, errCorrupt
+		}
+		return decodeDeltaByteArray(data, count)
+	case encByteStreamSplit:
+		return decodeByteStreamSplit(data, leaf.physical, leaf.typeLength, count)
+	}
+	return nil, series.NewError(series.ErrUnsupportedOperation, MsgEncoding, encoding)
+}
@@ -0,0 +1,314 @@
+/**
+  Copyright (c) [2024] [JiangNan]
+  [pandas] is licensed under Mulan PSL v2.
+  You can use this software according to the terms and conditions of the Mulan PSL v2.
+  You may obtain a copy of Mulan PSL v2 at:
+           http://license.coscl.org.cn/MulanPSL2
+  THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND, EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT, MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+  See the Mulan PSL v2 for more details.
+*/
+
+package parquet
+
+import (
+	"encoding/binary"
+	"io"
+	"math"
+)
+
+// Thrift compact 协议类型
+const (
+	tStop   byte = 0
+	tTrue   byte = 1
+	tFalse  byte = 2
+	tByte   byte = 3
+	tI16    byte = 4
+	tI32    byte = 5
+	tI64    byte = 6
+	tDouble byte = 7
+	tBinary byte = 8
+	tList   byte = 9
+	tSet    byte = 10
+	tMap    byte = 11
+	tRecord byte = 12
+)
+
+// 元数据嵌套深度上限，防止损坏的文件导致栈溢出
+const maxDepth = 64
+
+// 解码后的结构体，字段编号到值的映射。
+// 整数均为 int64，布尔值为 bool，浮点数为 float64，二进制为 []byte，列表为 []any，结构体为 tStruct
+type tStruct map[int16]any
+
+func (s tStruct) int(id int16) int64 {
+	v, _ := s[id].(int64)
+	return v
+}
+
+func (s tStruct) has(id int16) bool {
+	_, ok := s[id]
+	return ok
+}
+
+func (s tStruct) bool(id int16) bool {
+	v, _ := s[id].(bool)
+	return v
+}
+
+func (s tStruct) string(id int16) string {
+	v, _ := s[id].([]byte)
+	return string(v)
+}
+
+func (s tStruct) bytes(id int16) []byte {
+	v, _ := s[id].([]byte)
+	return v
+}
+
+func (s tStruct) list(id int16) []any {
+	v, _ := s[id].([]any)
+	return v
+}
+
+func (s tStruct) strct(id int16) tStruct {
+	v, _ := s[id].(tStruct)
+	return v
+}
+
+// Thrift compact 协议解码器
+type tReader struct {
+	r io.ByteReader
+}
+
+func (d *tReader) readStruct(depth int) (tStruct, error) {
+	if depth > maxDepth {
+		return nil, errThrift
+	}
+	s := make(tStruct)
+	var id int16
+	for {
+		b, err := d.r.ReadByte()
+		if err != nil {
+			return nil, err
+		}
+		typ := b & 0x0f
+		if typ == tStop {
+			return s, nil
+		}
+		if delta := int16(b >> 4); delta != 0 {
+			id += delta
+		} else {
+			v, err := d.readVarint()
+			if err != nil {
+				return nil, err
+			}
+			id = int16(v)
+		}
+		v, err := d.readValue(typ, depth)
+		if err != nil {
+			return nil, err
+		}
+		s[id] = v
+	}
+}
+
+func (d *tReader) readValue(typ byte, depth int) (any, error) {
+	switch typ {
+	case tTrue:
+		return true, nil
+	case tFalse:
+		return false, nil
+	case tByte:
+		b, err := d.r.ReadByte()
+		return int64(int8(b)), err
+	case tI16, tI32, tI64:
+		return d.readVarint()
+	case tDouble:
+		var b [8]byte
+		for i := range b {
+			c, err := d.r.ReadByte()
+			if err != nil {
+				return nil, err
+			}
+			b[i] = c
+		}
+		return math.Float64frombits(binary.LittleEndian.Uint64(b[:])), nil
+	case tBinary:
+		n, err := binary.ReadUvarint(d.r)
+		if err != nil {
+			return nil, err
+		}
+		if n > math.MaxInt32 {
+			return nil, errThrift
+		}
+		b := make([]byte, 0, min(n, 1<<16))
+		for i := uint64(0); i < n; i++ {
+			c, err := d.r.ReadByte()
+			if err != nil {
+				return nil, err
+			}
+			b = append(b, c)
+		}
+		return b, nil
+	case tList, tSet:
+		h, err := d.r.ReadByte()
+		if err != nil {
+			return nil, err
+		}
+		n := uint64(h >> 4)
+		if n == 15 {
+			if n, err = binary.ReadUvarint(d.r); err != nil {
+				return nil, err
+			}
+		}
+		if n > math.MaxInt32 {
+			return nil, errThrift
+		}
+		elem := h & 0x0f
+		items := make([]any, 0, min(n, 1<<10))
+		for i := uint64(0); i < n; i++ {
+			var v any
+			if elem == tTrue || elem == tFalse {
+				b, err := d.r.ReadByte()
+				if err != nil {
+					return nil, err
+				}
+				v = b == tTrue
+			} else if v, err = d.readValue(elem, depth+1); err != nil {
+				return nil, err
+			}
+			items = append(items, v)
+		}
+		return items, nil
+	case tMap:
+		n, err := binary.ReadUvarint(d.r)
+		if err != nil {
+			return nil, err
+		}
+		if n == 0 {
+			return nil, nil
+		}
+		h, err := d.r.ReadByte()
+		if err != nil {
+			return nil, err
+		}
+		for i := uint64(0); i < n; i++ {
+			if _, err = d.readValue(h>>4, depth+1); err != nil {
+				return nil, err
+			}
+			if _, err = d.readValue(h&0x0f, depth+1); err != nil {
+				return nil, err
+			}
+		}
+		return nil, nil
+	case tRecord:
+		return d.readStruct(depth + 1)
+	default:
+		return nil, errThrift
+	}
+}
+
+// 读取 zigzag 编码的变长整数
+func (d *tReader) readVarint() (int64, error) {
+	u, err := binary.ReadUvarint(d.r)
+	return int64(u>>1) ^ -int64(u&1), err
+}
+
+// 待编码的结构体字段，值可以为 bool、int32、int64、string、[]byte、tStruct 编码字段或 tListOf
+type tField struct {
+	id    int16
+	value any
+}
+
+// 待编码的列表
+type tListOf struct {
+	elem  byte
+	items []any
+}
+
+// Thrift compact 协议编码器
+type tWriter struct {
+	buf []byte
+}
+
+func (e *tWriter) writeStruct(fields []tField) {
+	var last int16
+	for _, f := range fields {
+		if f.value == nil {
+			continue
+		}
+		typ := typeOf(f.value)
+		if b, ok := f.value.(bool); ok {
+			typ = tFalse
+			if b {
+				typ = tTrue
+			}
+		}
+		if delta := f.id - last; delta > 0 && delta <= 15 {
+			e.buf = append(e.buf, byte(delta)<<4|typ)
+		} else {
+			e.buf = append(e.buf, typ)
+			e.writeVarint(int64(f.id))
+		}
+		last = f.id
+		if typ != tTrue && typ != tFalse {
+			e.writeValue(f.value)
+		}
+	}
+	e.buf = append(e.buf, tStop)
+}
+
+func (e *tWriter) writeValue(v any) {
+	switch x := v.(type) {
+	case bool:
+		if x {
+			e.buf = append(e.buf, tTrue)
+		} else {
+			e.buf = append(e.buf, tFalse)
+		}
+	case int32:
+		e.writeVarint(int64(x))
+	case int64:
+		e.writeVarint(x)
+	case string:
+		e.buf = binary.AppendUvarint(e.buf, uint64(len(x)))
+		e.buf = append(e.buf, x...)
+	case []byte:
+		e.buf = binary.AppendUvarint(e.buf, uint64(len(x)))
+		e.buf = append(e.buf, x...)
+	case []tField:
+		e.writeStruct(x)
+	case tListOf:
+		if n := len(x.items); n < 15 {
+			e.buf = append(e.buf, byte(n)<<4|x.elem)
+		} else {
+			e.buf = append(e.buf, 0xf0|x.elem)
+			e.buf = binary.AppendUvarint(e.buf, uint64(n))
+		}
+		for _, item := range x.items {
+			e.writeValue(item)
+		}
+	}
+}
+
+func (e *tWriter) writeVarint(v int64) {
+	e.buf = binary.AppendUvarint(e.buf, uint64(v<<1)^uint64(v>>63))
+}
+
+func typeOf(v any) byte {
+	switch v.(type) {
+	case bool:
+		return tTrue
+	case int32:
+		return tI32
+	case int64:
+		return tI64
+	case string, []byte:
+		return tBinary
+	case []tField:
+		return tRecord
+	case tListOf:
+		return tList
+	}
+	return tStop
+}
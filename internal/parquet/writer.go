@@ -0,0 +1,322 @@
+/**
+  Copyright (c) [2024] [JiangNan]
+  [pandas] is licensed under Mulan PSL v2.
+  You can use this software according to the terms and conditions of the Mulan PSL v2.
+  You may obtain a copy of Mulan PSL v2 at:
+           http://license.coscl.org.cn/MulanPSL2
+  THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND, EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT, MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+  See the Mulan PSL v2 for more details.
+*/
+
+package parquet
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"fmt"
+	"gitee.com/jn-qq/pandas/series"
+	"io"
+	"math"
+)
+
+// Field 写入的列
+type Field struct {
+	Name string
+	Kind Kind // 值类型，列表列为元素类型
+	List bool // 是否为列表列
+}
+
+// Compressor 压缩 Snappy、Gzip 以外压缩格式的页数据
+type Compressor func(codec Codec, data []byte) ([]byte, error)
+
+// Writer Parquet 文件写入器，基本类型列写为可空列，列表列写为三级 LIST 结构
+type Writer struct {
+	w         io.Writer
+	fields    []Field
+	codec     Codec
+	compress  Compressor
+	offset    int64
+	numRows   int64
+	rowGroups []any
+}
+
+// NewWriter 创建写入器并写入文件头，codec 为 Snappy、Gzip 以外的格式时由 compress 压缩
+func NewWriter(w io.Writer, fields []Field, codec Codec, compress Compressor) (*Writer, error) {
+	if codec != Uncompressed && codec != Snappy && codec != Gzip && compress == nil {
+		return nil, series.NewError(series.ErrInvalidArgument, MsgCodec, codec)
+	}
+	pw := &Writer{w: w, fields: fields, codec: codec, compress: compress}
+	if err := pw.write([]byte(magic)); err != nil {
+		return nil, err
+	}
+	return pw, nil
+}
+
+func (w *Writer) write(b []byte) error {
+	n, err := w.w.Write(b)
+	w.offset += int64(n)
+	return err
+}
+
+// WriteRowGroup 写入一个行组，columns 与 fields 一一对应，每行一个值：空值为 nil，
+// 基本类型列为 int、float64、string 或 bool，列表列为 []any
+func (w *Writer) WriteRowGroup(columns [][]any) error {
+	if len(columns) != len(w.fields) {
+		return &series.LengthMismatchError{Want: len(w.fields), Got: len(columns)}
+	}
+	rows := 0
+	if len(columns) > 0 {
+		rows = len(columns[0])
+	}
+	chunks := make([]any, 0, len(columns))
+	var totalSize, compressedSize int64
+	start := w.offset
+	for i, column := range columns {
+		if len(column) != rows {
+			return columnError(&series.LengthMismatchError{Want: rows, Got: len(column)}, w.fields[i].Name)
+		}
+		chunk, size, compressed, err := w.writeColumn(w.fields[i], column)
+		if err != nil {
+			return err
+		}
+		chunks = append(chunks, chunk)
+		totalSize += size
+		compressedSize += compressed
+	}
+	w.rowGroups = append(w.rowGroups, []tField{
+		{1, tListOf{tRecord, chunks}},
+		{2, totalSize},
+		{3, int64(rows)},
+		{5, start},
+		{6, compressedSize},
+	})
+	w.numRows += int64(rows)
+	return nil
+}
+
+// 以单个数据页写入列块，返回列块元数据及未压缩、压缩后的大小
+func (w *Writer) writeColumn(field Field, column []any) ([]tField, int64, int64, error) {
+	var repLevels, defLevels []int32
+	var values []any
+	maxDef := 1
+	if field.List {
+		maxDef = 3
+		for _, value := range column {
+			if value == nil {
+				repLevels, defLevels = append(repLevels, 0), append(defLevels, 0)
+				continue
+			}
+			items, ok := value.([]any)
+			if !ok {
+				return nil, 0, 0, columnError(&series.TypeMismatchError{Want: "[]any", Got: fmt.Sprintf("%T", value)}, field.Name)
+			}
+			if len(items) == 0 {
+				repLevels, defLevels = append(repLevels, 0), append(defLevels, 1)
+				continue
+			}
+			for j, item := range items {
+				rep := int32(1)
+				if j == 0 {
+					rep = 0
+				}
+				repLevels = append(repLevels, rep)
+				if item == nil {
+					defLevels = append(defLevels, 2)
+				} else {
+					defLevels = append(defLevels, 3)
+					values = append(values, item)
+				}
+			}
+		}
+	} else {
+		for _, value := range column {
+			if value == nil {
+				defLevels = append(defLevels, 0)
+			} else {
+				defLevels = append(defLevels, 1)
+				values = append(values, value)
+			}
+		}
+	}
+
+	var body []byte
+	if field.List {
+		body = appendLevels(body, repLevels, 1)
+	}
+	body = appendLevels(body, defLevels, maxDef)
+	body, err := appendPlain(body, field, values)
+	if err != nil {
+		return nil, 0, 0, err
+	}
+	compressed, err := w.compressPage(body)
+	if err != nil {
+		return nil, 0, 0, err
+	}
+	var header tWriter
+	header.writeStruct([]tField{
+		{1, dataPage},
+		{2, int32(len(body))},
+		{3, int32(len(compressed))},
+		{5, []tField{
+			{1, int32(len(defLevels))},
+			{2, encPlain},
+			{3, encRLE},
+			{4, encRLE},
+		}},
+	})
+	offset := w.offset
+	if err = w.write(header.buf); err != nil {
+		return nil, 0, 0, err
+	}
+	if err = w.write(compressed); err != nil {
+		return nil, 0, 0, err
+	}
+	size := int64(len(header.buf) + len(body))
+	compressedSize := int64(len(header.buf) + len(compressed))
+
+	path := []any{field.Name}
+	if field.List {
+		path = append(path, "list", "element")
+	}
+	meta := []tField{
+		{1, physicalOf(field.Kind)},
+		{2, tListOf{tI32, []any{encPlain, encRLE}}},
+		{3, tListOf{tBinary, path}},
+		{4, int32(w.codec)},
+		{5, int64(len(defLevels))},
+		{6, size},
+		{7, compressedSize},
+		{9, offset},
+	}
+	return []tField{{2, offset}, {3, meta}}, size, compressedSize, nil
+}
+
+func (w *Writer) compressPage(data []byte) ([]byte, error) {
+	switch w.codec {
+	case Uncompressed:
+		return data, nil
+	case Snappy:
+		return snappyEncode(data), nil
+	case Gzip:
+		var buf bytes.Buffer
+		zw := gzip.NewWriter(&buf)
+		if _, err := zw.Write(data); err != nil {
+			return nil, err
+		}
+		if err := zw.Close(); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	default:
+		return w.compress(w.codec, data)
+	}
+}
+
+// 写入带 4 字节长度前缀的 RLE 级别
+func appendLevels(buf []byte, levels []int32, max int) []byte {
+	start := len(buf)
+	buf = append(buf, 0, 0, 0, 0)
+	buf = appendHybrid(buf, levels, bitWidth(max))
+	binary.LittleEndian.PutUint32(buf[start:], uint32(len(buf)-start-4))
+	return buf
+}
+
+// 以 PLAIN 编码写入非空值
+func appendPlain(buf []byte, field Field, values []any) ([]byte, error) {
+	if field.Kind == KindBool {
+		packed := make([]byte, (len(values)+7)/8)
+		for i, value := range values {
+			b, ok := value.(bool)
+			if !ok {
+				return nil, columnError(&series.TypeMismatchError{Want: "bool", Got: fmt.Sprintf("%T", value)}, field.Name)
+			}
+			if b {
+				packed[i/8] |= 1 << (i % 8)
+			}
+		}
+		return append(buf, packed...), nil
+	}
+	for _, value := range values {
+		switch v := value.(type) {
+		case int:
+			if field.Kind != KindInt {
+				return nil, columnError(&series.TypeMismatchError{Want: field.Kind.String(), Got: "int"}, field.Name)
+			}
+			buf = binary.LittleEndian.AppendUint64(buf, uint64(v))
+		case float64:
+			if field.Kind != KindFloat {
+				return nil, columnError(&series.TypeMismatchError{Want: field.Kind.String(), Got: "float64"}, field.Name)
+			}
+			buf = binary.LittleEndian.AppendUint64(buf, math.Float64bits(v))
+		case string:
+			if field.Kind != KindString {
+				return nil, columnError(&series.TypeMismatchError{Want: field.Kind.String(), Got: "string"}, field.Name)
+			}
+			buf = binary.LittleEndian.AppendUint32(buf, uint32(len(v)))
+			buf = append(buf, v...)
+		default:
+			return nil, columnError(&series.TypeMismatchError{Want: field.Kind.String(), Got: fmt.Sprintf("%T", value)}, field.Name)
+		}
+	}
+	return buf, nil
+}
+
+func physicalOf(kind Kind) int32 {
+	switch kind {
+	case KindInt:
+		return Int64
+	case KindFloat:
+		return Double
+	case KindBool:
+		return Boolean
+	default:
+		return ByteArray
+	}
+}
+
+// 模式元素
+func (w *Writer) schema() []any {
+	elements := []any{[]tField{
+		{4, "schema"},
+		{5, int32(len(w.fields))},
+	}}
+	for _, field := range w.fields {
+		leaf := []tField{
+			{1, physicalOf(field.Kind)},
+			{3, optional},
+			{4, field.Name},
+		}
+		if field.Kind == KindString {
+			leaf = append(leaf, tField{6, convUTF8}, tField{10, []tField{{1, []tField{}}}})
+		}
+		if !field.List {
+			elements = append(elements, leaf)
+			continue
+		}
+		leaf[2].value = "element"
+		elements = append(elements,
+			[]tField{{3, optional}, {4, field.Name}, {5, int32(1)}, {6, convList}, {10, []tField{{3, []tField{}}}}},
+			[]tField{{3, repeated}, {4, "list"}, {5, int32(1)}},
+			leaf,
+		)
+	}
+	return elements
+}
+
+// Close 写入文件元数据及文件尾，不关闭底层写入器
+func (w *Writer) Close() error {
+	var meta tWriter
+	meta.writeStruct([]tField{
+		{1, int32(1)},
+		{2, tListOf{tRecord, w.schema()}},
+		{3, w.numRows},
+		{4, tListOf{tRecord, w.rowGroups}},
+		{6, "gitee.com/jn-qq/pandas"},
+	})
+	if err := w.write(meta.buf); err != nil {
+		return err
+	}
+	tail := binary.LittleEndian.AppendUint32(nil, uint32(len(meta.buf)))
+	return w.write(append(tail, magic...))
+}
@@ -0,0 +1,281 @@
+/**
+  Copyright (c) [2024] [JiangNan]
+  [pandas] is licensed under Mulan PSL v2.
+  You can use this software according to the terms and conditions of the Mulan PSL v2.
+  You may obtain a copy of Mulan PSL v2 at:
+           http://license.coscl.org.cn/MulanPSL2
+  THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND, EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT, MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+  See the Mulan PSL v2 for more details.
+*/
+
+package series
+
+import (
+	"encoding/binary"
+	"math"
+	"time"
+)
+
+// 二进制格式版本，格式不兼容时递增；同一版本中新增的字段追加在末尾，旧版本读取时忽略。
+// 版本 2 起字符串以长度加一开头，0 表示空值；版本 1 的字符串 "NaN" 读取为空值
+const binaryVersion = 2
+
+// 索引的保存方式
+const (
+	indexDefault  byte = 0 // 0 至 n-1，读取时由 InitIndex 生成
+	indexExplicit byte = 1 // 逐个保存
+)
+
+// MarshalBinary 实现 encoding.BinaryMarshaler，原样保存名称、类型、元素及索引：
+// 整数、字符串、布尔值按值保存，字符串区分空值与 "NaN"，浮点数保存完整的位模式（含 NaN 的负载位），日期时间保存时刻及时区偏移，列表区分空值与空列表
+func (s *Series) MarshalBinary() ([]byte, error) {
+	if !knownType(s.t) {
+		return nil, NewError(ErrUnknownType, MsgUnknownType)
+	}
+	b := []byte{binaryVersion}
+	b = appendString(b, s.Name)
+	b = appendString(b, string(s.t))
+	b = binary.AppendUvarint(b, uint64(len(s.elements)))
+	for _, e := range s.elements {
+		b = appendElement(b, e)
+	}
+	if isDefaultIndex(s.indexes, len(s.elements)) {
+		return append(b, indexDefault), nil
+	}
+	b = append(b, indexExplicit)
+	b = binary.AppendUvarint(b, uint64(len(s.indexes)))
+	for _, index := range s.indexes {
+		b = binary.AppendVarint(b, int64(index))
+	}
+	return b, nil
+}
+
+// UnmarshalBinary 实现 encoding.BinaryUnmarshaler，读取 MarshalBinary 保存的数据，
+// 版本高于当前版本时返回 ErrInvalidArgument，版本为 0（从未使用）时视为数据损坏
+func (s *Series) UnmarshalBinary(data []byte) error {
+	d := &binaryDecoder{b: data}
+	switch d.version = d.byte(); {
+	case d.err != nil:
+	case d.version == 0:
+		return ErrCorruptData
+	case d.version > binaryVersion:
+		return NewError(ErrInvalidArgument, MsgUnsupportedVersion, d.version)
+	}
+	name := d.string()
+	t := Type(d.string())
+	if d.err == nil && !knownType(t) {
+		return NewError(ErrUnknownType, MsgUnknownType)
+	}
+	n := d.count(1)
+	ns := &Series{Name: name, t: t}
+	if d.err == nil {
+		ns.elements = make([]Element, 0, n)
+	}
+	for i := 0; i < n && d.err == nil; i++ {
+		ns.elements = append(ns.elements, d.element(t))
+	}
+	switch d.byte() {
+	case indexDefault:
+		ns.InitIndex()
+	case indexExplicit:
+		m := d.count(1)
+		if d.err == nil {
+			ns.indexes = make([]int, 0, m)
+		}
+		for i := 0; i < m && d.err == nil; i++ {
+			ns.indexes = append(ns.indexes, int(d.varint()))
+		}
+	default:
+		d.fail()
+	}
+	if d.err != nil {
+		return d.err
+	}
+	*s = *ns
+	return nil
+}
+
+// 是否为可保存的数据类型
+func knownType(t Type) bool {
+	switch t.Elem() {
+	case String, Int, Float, Bool:
+		return t == t.Elem() || t == List(t.Elem())
+	case Datetime:
+		return t == Datetime
+	}
+	return false
+}
+
+// 索引是否为 0 至 n-1
+func isDefaultIndex(indexes []int, n int) bool {
+	if len(indexes) != n {
+		return false
+	}
+	for i, index := range indexes {
+		if index != i {
+			return false
+		}
+	}
+	return true
+}
+
+func appendString(b []byte, v string) []byte {
+	b = binary.AppendUvarint(b, uint64(len(v)))
+	return append(b, v...)
+}
+
+// 追加元素的值，字符串、列表以长度加一开头，0 表示空值
+func appendElement(b []byte, e Element) []byte {
+	switch x := e.(type) {
+	case *stringElement:
+		if x.isNaN() {
+			return append(b, 0)
+		}
+		b = binary.AppendUvarint(b, uint64(len(*x))+1)
+		return append(b, *x...)
+	case *intElement:
+		return binary.AppendVarint(b, int64(*x))
+	case *floatElement:
+		return binary.LittleEndian.AppendUint64(b, math.Float64bits(float64(*x)))
+	case *boolElement:
+		if *x {
+			return append(b, 1)
+		}
+		return append(b, 0)
+	case *timeElement:
+		// time.Time.MarshalBinary 仅在时区偏移不是整分钟时出错，此时按 UTC 保存
+		data, err := time.Time(*x).MarshalBinary()
+		if err != nil {
+			data, _ = time.Time(*x).UTC().MarshalBinary()
+		}
+		return appendString(b, string(data))
+	case *listElement:
+		if x.values == nil {
+			return append(b, 0)
+		}
+		b = binary.AppendUvarint(b, uint64(len(x.values))+1)
+		for _, value := range x.values {
+			b = appendElement(b, value)
+		}
+	}
+	return b
+}
+
+// 二进制数据读取器，出错后各方法返回零值
+type binaryDecoder struct {
+	b       []byte
+	version byte
+	err     error
+}
+
+func (d *binaryDecoder) fail() {
+	if d.err == nil {
+		d.err = ErrCorruptData
+	}
+	d.b = nil
+}
+
+func (d *binaryDecoder) byte() byte {
+	if len(d.b) == 0 {
+		d.fail()
+		return 0
+	}
+	v := d.b[0]
+	d.b = d.b[1:]
+	return v
+}
+
+func (d *binaryDecoder) uvarint() uint64 {
+	v, n := binary.Uvarint(d.b)
+	if n <= 0 {
+		d.fail()
+		return 0
+	}
+	d.b = d.b[n:]
+	return v
+}
+
+func (d *binaryDecoder) varint() int64 {
+	v, n := binary.Varint(d.b)
+	if n <= 0 {
+		d.fail()
+		return 0
+	}
+	d.b = d.b[n:]
+	return v
+}
+
+// 读取个数，每项至少占 size 字节，超出剩余数据时视为损坏，避免按损坏的长度分配内存
+func (d *binaryDecoder) count(size int) int {
+	n := d.uvarint()
+	if n > uint64(len(d.b)/size) {
+		d.fail()
+		return 0
+	}
+	return int(n)
+}
+
+func (d *binaryDecoder) string() string {
+	n := d.count(1)
+	v := string(d.b[:n])
+	d.b = d.b[n:]
+	return v
+}
+
+func (d *binaryDecoder) element(t Type) Element {
+	switch t {
+	case String:
+		if d.version == 1 {
+			x := stringElement(d.string())
+			if x == "NaN" {
+				x = stringNaN
+			}
+			return &x
+		}
+		x := stringNaN
+		if n := d.uvarint(); n > 0 {
+			if n-1 > uint64(len(d.b)) {
+				d.fail()
+				return &x
+			}
+			x = stringElement(d.b[:n-1])
+			d.b = d.b[n-1:]
+		}
+		return &x
+	case Int:
+		x := intElement(d.varint())
+		return &x
+	case Float:
+		if len(d.b) < 8 {
+			d.fail()
+			return new(floatElement)
+		}
+		x := floatElement(math.Float64frombits(binary.LittleEndian.Uint64(d.b)))
+		d.b = d.b[8:]
+		return &x
+	case Bool:
+		x := boolElement(d.byte() != 0)
+		return &x
+	case Datetime:
+		var v time.Time
+		if err := v.UnmarshalBinary([]byte(d.string())); err != nil {
+			d.fail()
+		}
+		x := timeElement(v)
+		return &x
+	}
+	l := &listElement{t: t.Elem()}
+	n := d.uvarint()
+	if n == 0 {
+		return l
+	}
+	if n-1 > uint64(len(d.b)) {
+		d.fail()
+		return l
+	}
+	l.values = make([]Element, 0, n-1)
+	for i := uint64(1); i < n && d.err == nil; i++ {
+		l.values = append(l.values, d.element(t.Elem()))
+	}
+	return l
+}
@@ -0,0 +1,199 @@
+/**
+  Copyright (c) [2024] [JiangNan]
+  [pandas] is licensed under Mulan PSL v2.
+  You can use this software according to the terms and conditions of the Mulan PSL v2.
+  You may obtain a copy of Mulan PSL v2 at:
+           http://license.coscl.org.cn/MulanPSL2
+  THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND, EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT, MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+  See the Mulan PSL v2 for more details.
+*/
+
+package series
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"time"
+)
+
+// 字符串转换为 Datetime 时依次尝试的格式，不含时区的格式按 UTC 解析，秒后可带小数
+var timeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02 15:04:05Z07:00",
+	"2006-01-02 15:04:05",
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02",
+	"2006/01/02 15:04:05",
+	"2006/01/02 15:04",
+	"2006/01/02",
+}
+
+// 数据类型对应的 Go 类型名，Datetime 为 time.Time
+func (t Type) goType() string {
+	if t == Datetime {
+		return "time.Time"
+	}
+	return string(t)
+}
+
+// 日期时间数据格式，实现接口 Element。零值表示空值
+type timeElement time.Time
+
+// 按 timeLayouts 解析字符串
+func parseTime(value string) (time.Time, bool) {
+	for _, layout := range timeLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// 返回元素的时间，非日期时间元素返回零值
+func timeOf(e Element) time.Time {
+	if x, ok := e.(*timeElement); ok {
+		return time.Time(*x)
+	}
+	return time.Time{}
+}
+
+func (t *timeElement) Set(value any) {
+	switch val := value.(type) {
+	case time.Time:
+		*t = timeElement(val)
+	case string:
+		v, _ := parseTime(val)
+		*t = timeElement(v)
+	case int:
+		// 整数为 Unix 秒
+		if val == math.MinInt {
+			*t = timeElement{}
+		} else {
+			*t = timeElement(time.Unix(int64(val), 0).UTC())
+		}
+	case float64:
+		if math.IsNaN(val) || math.IsInf(val, 0) {
+			*t = timeElement{}
+		} else {
+			sec, frac := math.Modf(val)
+			*t = timeElement(time.Unix(int64(sec), int64(frac*1e9)).UTC())
+		}
+	default:
+		*t = timeElement{}
+	}
+}
+
+func (t *timeElement) TrySet(value any) error {
+	switch val := value.(type) {
+	case string:
+		if _, ok := parseTime(val); !ok && !slices.Contains(naValues, val) {
+			return &ParseError{Index: -1, Value: val, Type: Datetime}
+		}
+	case time.Time, int, float64:
+	default:
+		return &TypeMismatchError{Want: string(Datetime), Got: fmt.Sprintf("%T", value)}
+	}
+	t.Set(value)
+	return nil
+}
+
+// Records UTC 零点为 "2006-01-02"，其余 UTC 时间为 "2006-01-02 15:04:05"（秒后按需带小数），其他时区为 RFC 3339 格式
+func (t *timeElement) Records() string {
+	if t.isNaN() {
+		return "NaN"
+	}
+	v := time.Time(*t)
+	switch {
+	case v.Location() != time.UTC:
+		return v.Format(time.RFC3339Nano)
+	case v.Hour() == 0 && v.Minute() == 0 && v.Second() == 0 && v.Nanosecond() == 0:
+		return v.Format("2006-01-02")
+	default:
+		return v.Format("2006-01-02 15:04:05.999999999")
+	}
+}
+
+// Int 返回 Unix 秒
+func (t *timeElement) Int() int {
+	if t.isNaN() {
+		return math.MinInt
+	}
+	return int(time.Time(*t).Unix())
+}
+
+// Float 返回带小数的 Unix 秒
+func (t *timeElement) Float() float64 {
+	if t.isNaN() {
+		return math.NaN()
+	}
+	v := time.Time(*t)
+	return float64(v.Unix()) + float64(v.Nanosecond())/1e9
+}
+
+func (t *timeElement) Bool() bool {
+	return !t.isNaN()
+}
+
+func (t *timeElement) Value() any {
+	return time.Time(*t)
+}
+
+func (t *timeElement) dType() string {
+	return string(Datetime)
+}
+
+func (t *timeElement) copy() Element {
+	t2 := new(timeElement)
+	*t2 = *t
+	return t2
+}
+
+func (t *timeElement) isNaN() bool {
+	return time.Time(*t).IsZero()
+}
+
+func (t *timeElement) update(elem Element) {
+	switch x := elem.(type) {
+	case *timeElement:
+		*t = *x
+	case *intElement, *floatElement:
+		t.Set(x.Value())
+	default:
+		t.Set(elem.Records())
+	}
+}
+
+func (t *timeElement) String() string {
+	return t.Records()
+}
+
+// 日期时间元素是否满足关系运算，空值仅满足 NotEqual、NotIn
+func matchTime(operator RelationalOperator, element Element, values any) bool {
+	v := timeOf(element)
+	switch operator {
+	case In, NotIn:
+		found := !element.isNaN() && slices.ContainsFunc(values.([]time.Time), v.Equal)
+		return found == (operator == In)
+	}
+	if element.isNaN() {
+		return operator == NotEqual
+	}
+	c := v.Compare(values.(time.Time))
+	switch operator {
+	case Equal:
+		return c == 0
+	case NotEqual:
+		return c != 0
+	case LessThan:
+		return c < 0
+	case LessOrEqual:
+		return c <= 0
+	case GreaterThan:
+		return c > 0
+	case GreaterOrEqual:
+		return c >= 0
+	}
+	return false
+}
@@ -19,8 +19,10 @@ import (
 )
 
 type Element interface {
-	// Set 设置值
+	// Set 设置值，无法转换时置为空值
 	Set(any)
+	// TrySet 设置值，类型不支持或无法转换时返回错误且不修改元素
+	TrySet(any) error
 	// Records 返回字符串
 	Records() string
 	// Int 返回整数
@@ -44,6 +46,9 @@ type Element interface {
 // 字符串数据格式，实现接口 Element
 type stringElement string
 
+// 字符串的空值，Records 返回 "NaN"，与按原值保存的字符串 "NaN" 区分
+const stringNaN stringElement = "\x00NaN"
+
 // 整数数据格式，实现接口 Element
 type intElement int
 
@@ -60,8 +65,8 @@ type boolElement bool
 func (s *stringElement) Set(value any) {
 	switch val := value.(type) {
 	case string:
-		if slices.Contains([]string{"", "NaN", "nan", "null", "Null"}, val) {
-			*s = "NaN"
+		if slices.Contains(naValues, val) {
+			*s = stringNaN
 		} else {
 			*s = stringElement(val)
 		}
@@ -76,7 +81,7 @@ func (s *stringElement) Set(value any) {
 			*s = "false"
 		}
 	default:
-		*s = "NaN"
+		*s = stringNaN
 	}
 }
 func (i *intElement) Set(value any) {
@@ -90,7 +95,11 @@ func (i *intElement) Set(value any) {
 	case int:
 		*i = intElement(val)
 	case float64:
-		*i = intElement(val)
+		if math.IsNaN(val) || math.IsInf(val, 0) {
+			*i = math.MinInt
+		} else {
+			*i = intElement(val)
+		}
 	case bool:
 		if val {
 			*i = 1
@@ -126,11 +135,9 @@ func (f *floatElement) Set(value any) {
 func (b *boolElement) Set(value any) {
 	switch val := value.(type) {
 	case string:
-		if slices.Contains([]string{"false", "0", "F", "f"}, val) {
-			*b = false
-		} else {
-			*b = true
-		}
+		// 取值同 strconv.ParseBool，其他字符串视为 true
+		v, err := strconv.ParseBool(val)
+		*b = boolElement(v || err != nil)
 	case int, float64:
 		if val == 0 {
 			*b = false
@@ -140,13 +147,69 @@ func (b *boolElement) Set(value any) {
 	case bool:
 		*b = boolElement(val)
 	default:
-		panic("错误的数据类型")
+		// 布尔值没有空值，不支持的类型置为 false
+		*b = false
 	}
 }
 
 //--------------------------------//
 
+func (s *stringElement) TrySet(value any) error {
+	switch value.(type) {
+	case string, int, float64, bool:
+		s.Set(value)
+		return nil
+	}
+	return &TypeMismatchError{Want: string(String), Got: fmt.Sprintf("%T", value)}
+}
+func (i *intElement) TrySet(value any) error {
+	switch val := value.(type) {
+	case string:
+		if _, err := strconv.Atoi(val); err != nil && !slices.Contains(naValues, val) {
+			return &ParseError{Index: -1, Value: val, Type: Int}
+		}
+	case int, float64, bool:
+	default:
+		return &TypeMismatchError{Want: string(Int), Got: fmt.Sprintf("%T", value)}
+	}
+	i.Set(value)
+	return nil
+}
+func (f *floatElement) TrySet(value any) error {
+	switch val := value.(type) {
+	case string:
+		if _, err := strconv.ParseFloat(val, 64); err != nil && !slices.Contains(naValues, val) {
+			return &ParseError{Index: -1, Value: val, Type: Float}
+		}
+	case int, float64, bool:
+	default:
+		return &TypeMismatchError{Want: string(Float), Got: fmt.Sprintf("%T", value)}
+	}
+	f.Set(value)
+	return nil
+}
+func (b *boolElement) TrySet(value any) error {
+	switch val := value.(type) {
+	case string:
+		v, err := strconv.ParseBool(val)
+		if err != nil {
+			return &ParseError{Index: -1, Value: val, Type: Bool}
+		}
+		*b = boolElement(v)
+		return nil
+	case int, float64, bool:
+		b.Set(value)
+		return nil
+	}
+	return &TypeMismatchError{Want: string(Bool), Got: fmt.Sprintf("%T", value)}
+}
+
+//--------------------------------//
+
 func (s *stringElement) Records() string {
+	if s.isNaN() {
+		return "NaN"
+	}
 	return string(*s)
 }
 func (i *intElement) Records() string {
@@ -176,7 +239,6 @@ func (s *stringElement) Int() int {
 		return math.MinInt
 	}
 	if i, err := strconv.Atoi(string(*s)); err != nil {
-		fmt.Printf("%s 不能转换为 Int，已置为无限小\n", string(*s))
 		return math.MinInt
 	} else {
 		return i
@@ -293,7 +355,7 @@ func (b *boolElement) copy() Element {
 //--------------------------------//
 
 func (s *stringElement) isNaN() bool {
-	if *s == "NaN" {
+	if *s == stringNaN {
 		return true
 	}
 	return false
@@ -334,6 +396,11 @@ func (i *intElement) String() string {
 //--------------------------------//
 
 func (s *stringElement) update(elem Element) {
+	// 直接复制，保留作为有效值的空字符串
+	if x, ok := elem.(*stringElement); ok {
+		*s = *x
+		return
+	}
 	s.Set(elem.Records())
 }
 
@@ -0,0 +1,86 @@
+/**
+  Copyright (c) [2024] [JiangNan]
+  [pandas] is licensed under Mulan PSL v2.
+  You can use this software according to the terms and conditions of the Mulan PSL v2.
+  You may obtain a copy of Mulan PSL v2 at:
+           http://license.coscl.org.cn/MulanPSL2
+  THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND, EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT, MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+  See the Mulan PSL v2 for more details.
+*/
+
+package series
+
+// 错误类型，可通过 errors.Is 判断，对应的结构化错误可通过 errors.As 获取详细信息，
+// 错误信息语言由 SetLanguage 设置，也可通过 Localize 按指定语言获取
+var (
+	// ErrLengthMismatch 长度不相等，对应 *LengthMismatchError
+	ErrLengthMismatch = NewError(nil, MsgLengthMismatch)
+	// ErrTypeMismatch 数据类型不匹配，对应 *TypeMismatchError
+	ErrTypeMismatch = NewError(nil, MsgTypeMismatch)
+	// ErrUnsupportedOperation 数据类型不支持该操作，对应 *UnsupportedOperationError
+	ErrUnsupportedOperation = NewError(nil, MsgUnsupportedOperation)
+	// ErrParse 字符串转换失败，对应 *ParseError
+	ErrParse = NewError(nil, MsgParse)
+	// ErrIndexOutOfRange 索引越界
+	ErrIndexOutOfRange = NewError(nil, MsgIndexOutOfRange)
+	// ErrUnknownType 未知数据类型
+	ErrUnknownType = NewError(nil, MsgUnknownType)
+	// ErrInvalidArgument 参数错误
+	ErrInvalidArgument = NewError(nil, MsgInvalidArgument)
+	// ErrCorruptData 数据已损坏或不是所需的格式，如读取的文件不完整
+	ErrCorruptData = NewError(nil, MsgCorruptData)
+)
+
+// LengthMismatchError 长度不相等
+type LengthMismatchError struct {
+	Want int // 期望长度
+	Got  int // 实际长度
+}
+
+func (e *LengthMismatchError) Error() string {
+	return e.Localize(Language())
+}
+
+func (e *LengthMismatchError) Localize(lang Lang) string {
+	return Message(lang, MsgLengthMismatchDetail, e.Want, e.Got)
+}
+
+func (e *LengthMismatchError) Is(target error) bool {
+	return target == ErrLengthMismatch
+}
+
+// TypeMismatchError 数据类型不匹配
+type TypeMismatchError struct {
+	Want string // 期望类型
+	Got  string // 实际类型
+}
+
+func (e *TypeMismatchError) Error() string {
+	return e.Localize(Language())
+}
+
+func (e *TypeMismatchError) Localize(lang Lang) string {
+	return Message(lang, MsgTypeMismatchDetail, e.Want, e.Got)
+}
+
+func (e *TypeMismatchError) Is(target error) bool {
+	return target == ErrTypeMismatch
+}
+
+// UnsupportedOperationError 数据类型不支持该操作
+type UnsupportedOperationError struct {
+	Op   string // 操作名称
+	Type Type   // 数据类型
+}
+
+func (e *UnsupportedOperationError) Error() string {
+	return e.Localize(Language())
+}
+
+func (e *UnsupportedOperationError) Localize(lang Lang) string {
+	return Message(lang, MsgUnsupportedOpDetail, e.Type, e.Op)
+}
+
+func (e *UnsupportedOperationError) Is(target error) bool {
+	return target == ErrUnsupportedOperation
+}
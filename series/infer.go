@@ -0,0 +1,84 @@
+/**
+  Copyright (c) [2024] [JiangNan]
+  [pandas] is licensed under Mulan PSL v2.
+  You can use this software according to the terms and conditions of the Mulan PSL v2.
+  You may obtain a copy of Mulan PSL v2 at:
+           http://license.coscl.org.cn/MulanPSL2
+  THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND, EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT, MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+  See the Mulan PSL v2 for more details.
+*/
+
+package series
+
+import (
+	"strconv"
+	"strings"
+)
+
+// InferType 根据字符串样本推断数据类型，空值不参与推断
+//
+//	全部为 true、false（不区分 TRUE、True 等写法，与 Bool 列的加载规则 strconv.ParseBool 一致，
+//	但不含 1、0、t、f 等单字符取值）时为 Bool，全部可解析为整数时为 Int，全部可解析为数值时为 Float，
+//	全部为 Datetime 支持的日期时间格式（如 2024-01-02、2024-01-02 15:04:05、RFC 3339）时为 Datetime，否则为 String。
+//	以 0 开头的多位数字（如编号、邮编）视为 String，样本全部为空值时为 String。
+//	opts: 使用其中的数值解析器及空值标记
+func InferType(values []string, opts LoadOptions) Type {
+	parser := opts.parser()
+	isBool, isInt, isFloat, isTime, n := true, true, true, true, 0
+	for _, value := range values {
+		if opts.isNA(value, Float) {
+			continue
+		}
+		n++
+		if isBool && !isBoolWord(value) {
+			isBool = false
+		}
+		if isTime {
+			if t, ok := parseTime(value); !ok || t.IsZero() {
+				isTime = false
+			}
+		}
+		if hasLeadingZero(value) {
+			isInt, isFloat = false, false
+		}
+		if isInt {
+			if _, err := parser.ParseInt(value); err != nil {
+				isInt = false
+			}
+		}
+		if isFloat {
+			if _, err := parser.ParseFloat(value); err != nil {
+				isFloat = false
+			}
+		}
+		if !isBool && !isFloat && !isTime {
+			return String
+		}
+	}
+	switch {
+	case n == 0:
+		return String
+	case isBool:
+		return Bool
+	case isInt:
+		return Int
+	case isFloat:
+		return Float
+	case isTime:
+		return Datetime
+	default:
+		return String
+	}
+}
+
+// 是否为可推断为 Bool 的取值，单字符取值不参与推断
+func isBoolWord(value string) bool {
+	_, err := strconv.ParseBool(value)
+	return err == nil && len(value) > 1
+}
+
+// 是否为以 0 开头的多位数字，如 "00123"
+func hasLeadingZero(value string) bool {
+	value = strings.TrimSpace(value)
+	return len(value) > 1 && value[0] == '0' && value[1] >= '0' && value[1] <= '9'
+}
@@ -0,0 +1,193 @@
+/**
+  Copyright (c) [2024] [JiangNan]
+  [pandas] is licensed under Mulan PSL v2.
+  You can use this software according to the terms and conditions of the Mulan PSL v2.
+  You may obtain a copy of Mulan PSL v2 at:
+           http://license.coscl.org.cn/MulanPSL2
+  THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND, EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT, MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+  See the Mulan PSL v2 for more details.
+*/
+
+package series
+
+import (
+	"bytes"
+	"encoding/json"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// JSON 格式数据列
+type jsonSeries struct {
+	Name string `json:"name"`
+	Type Type   `json:"type"`
+	Data []any  `json:"data"`
+}
+
+// MarshalJSON 实现 json.Marshaler，格式为 {"name":"age","type":"int","data":[12,null]}，空值记为 null
+func (s *Series) MarshalJSON() ([]byte, error) {
+	return json.Marshal(jsonSeries{Name: s.Name, Type: s.t, Data: s.JSONValues()})
+}
+
+// UnmarshalJSON 实现 json.Unmarshaler，格式同 MarshalJSON，未指定 type 时根据数据推断
+func (s *Series) UnmarshalJSON(data []byte) error {
+	var js jsonSeries
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	if err := dec.Decode(&js); err != nil {
+		return err
+	}
+	ns, err := LoadJSON(js.Data, js.Type, js.Name)
+	if err != nil {
+		return err
+	}
+	*s = *ns
+	return nil
+}
+
+// JSONValues 返回可由 encoding/json 编码的元素值，空值及无穷大为 nil，
+// 浮点数以 json.Number 表示且总带有小数点，使读取时可与整数区分
+func (s *Series) JSONValues() []any {
+	values := make([]any, 0, s.Len())
+	for _, element := range s.elements {
+		values = append(values, jsonValue(element))
+	}
+	return values
+}
+
+// 返回元素的 JSON 值
+func jsonValue(e Element) any {
+	if e.isNaN() {
+		return nil
+	}
+	switch x := e.(type) {
+	case *intElement:
+		return int(*x)
+	case *floatElement:
+		f := float64(*x)
+		if math.IsInf(f, 0) {
+			return nil
+		}
+		number := strconv.FormatFloat(f, 'g', -1, 64)
+		if !strings.ContainsAny(number, ".eE") {
+			number += ".0"
+		}
+		return json.Number(number)
+	case *boolElement:
+		return bool(*x)
+	case *listElement:
+		values := make([]any, 0, len(x.values))
+		for _, value := range x.values {
+			values = append(values, jsonValue(value))
+		}
+		return values
+	default:
+		return e.Records()
+	}
+}
+
+// LoadJSON 用 JSON 解码得到的值创建数据列，值为 nil 时置为空值，Bool 类型没有空值，置为 false；
+// 字符串按原值保存，只有 JSON 的 null 为空值
+//
+//	values: 由 encoding/json 解码的值，数字可以为 float64 或 json.Number
+//	t: 数据类型，为空时根据数据推断：整数为 Int，含小数为 Float，数组为 List(T)，类型不一致时为 String
+//	name: 数据列名称
+func LoadJSON(values []any, t Type, name string) (*Series, error) {
+	if t == "" {
+		t = InferJSONType(values)
+	}
+	items := make([]any, 0, len(values))
+	for _, value := range values {
+		items = append(items, fromJSON(value))
+	}
+	return LoadValues(items, t, name)
+}
+
+// 将 json.Number 转换为字符串，由元素按自身类型解析
+func fromJSON(value any) any {
+	switch x := value.(type) {
+	case json.Number:
+		return x.String()
+	case []any:
+		items := make([]any, 0, len(x))
+		for _, item := range x {
+			items = append(items, fromJSON(item))
+		}
+		return items
+	case map[string]any:
+		items := make(map[string]any, len(x))
+		for k, item := range x {
+			items[k] = fromJSON(item)
+		}
+		return items
+	default:
+		return value
+	}
+}
+
+// InferJSONType 推断 JSON 值的数据类型，全部为 nil 时为 String，列表均为空时为 List(String)
+func InferJSONType(values []any) Type {
+	switch t := inferJSONType(values); t {
+	case "":
+		return String
+	case List(""):
+		return List(String)
+	default:
+		return t
+	}
+}
+
+// 推断 JSON 值的数据类型，全部为 nil 时返回空
+func inferJSONType(values []any) Type {
+	var t Type
+	for _, value := range values {
+		var vt Type
+		switch x := value.(type) {
+		case nil:
+			continue
+		case bool:
+			vt = Bool
+		case int:
+			vt = Int
+		case float64:
+			if x == math.Trunc(x) && math.Abs(x) < 1<<53 {
+				vt = Int
+			} else {
+				vt = Float
+			}
+		case json.Number:
+			if _, err := strconv.Atoi(x.String()); err == nil {
+				vt = Int
+			} else {
+				vt = Float
+			}
+		case []any:
+			// 元素类型未知的空列表记为 "[]"，由其他列表确定元素类型
+			if elem := inferJSONType(x); elem.IsList() {
+				vt = String
+			} else {
+				vt = List(elem)
+			}
+		default:
+			vt = String
+		}
+		t = mergeJSONType(t, vt)
+	}
+	return t
+}
+
+// 合并两种推断类型，整数与浮点数合并为浮点数，其余不一致时为 String
+func mergeJSONType(a, b Type) Type {
+	switch {
+	case a == "" || a == b:
+		return b
+	case b == "":
+		return a
+	case (a == Int && b == Float) || (a == Float && b == Int):
+		return Float
+	case a.IsList() && b.IsList():
+		return List(mergeJSONType(a.Elem(), b.Elem()))
+	}
+	return String
+}
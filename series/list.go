@@ -0,0 +1,289 @@
+/**
+  Copyright (c) [2024] [JiangNan]
+  [pandas] is licensed under Mulan PSL v2.
+  You can use this software according to the terms and conditions of the Mulan PSL v2.
+  You may obtain a copy of Mulan PSL v2 at:
+           http://license.coscl.org.cn/MulanPSL2
+  THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND, EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT, MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+  See the Mulan PSL v2 for more details.
+*/
+
+package series
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// List 返回元素类型为 t 的列表类型，如 List(Int) 为 "[]int"
+func List(t Type) Type {
+	return "[]" + t
+}
+
+// IsList 判断是否为列表类型
+func (t Type) IsList() bool {
+	return strings.HasPrefix(string(t), "[]")
+}
+
+// Elem 返回列表类型的元素类型，非列表类型返回自身
+func (t Type) Elem() Type {
+	return Type(strings.TrimPrefix(string(t), "[]"))
+}
+
+// ListElement 列表元素，列表类型数据列中的元素可断言为该接口
+type ListElement interface {
+	Element
+	// Len 列表长度，空值返回 0
+	Len() int
+	// Get 返回第 i 个元素，索引越界时返回 nil
+	Get(i int) Element
+	// Contains 判断列表是否包含 value
+	Contains(value any) bool
+}
+
+// 列表数据格式，实现接口 ListElement。values 为 nil 时表示空值
+type listElement struct {
+	t      Type
+	values []Element
+}
+
+func (l *listElement) Set(value any) {
+	switch val := value.(type) {
+	case string:
+		var items []any
+		if err := json.Unmarshal([]byte(val), &items); err != nil || items == nil {
+			l.values = nil
+			return
+		}
+		l.Set(items)
+	case Element:
+		l.Set(val.Value())
+	default:
+		l.values = nil
+		if value == nil || reflect.TypeOf(value).Kind() != reflect.Slice {
+			return
+		}
+		v := reflect.ValueOf(value)
+		l.values = make([]Element, 0, v.Len())
+		for i := 0; i < v.Len(); i++ {
+			x := NewElements(l.t, 1)[0]
+			if item := v.Index(i).Interface(); item != nil {
+				x.Set(item)
+			} else if l.t != Bool {
+				x.Set("NaN")
+			}
+			l.values = append(l.values, x)
+		}
+	}
+}
+
+// Records 以 JSON 数组形式返回，空值元素记为 null
+func (l *listElement) Records() string {
+	if l.isNaN() {
+		return "NaN"
+	}
+	var items []string
+	for _, value := range l.values {
+		switch {
+		case value.isNaN():
+			items = append(items, "null")
+		case l.t == String:
+			b, _ := json.Marshal(value.Records())
+			items = append(items, string(b))
+		default:
+			items = append(items, value.Records())
+		}
+	}
+	return "[" + strings.Join(items, ",") + "]"
+}
+
+func (l *listElement) Int() int {
+	return math.MinInt
+}
+
+func (l *listElement) Float() float64 {
+	return math.NaN()
+}
+
+// Bool 非空列表为 true
+func (l *listElement) Bool() bool {
+	return l.Len() > 0
+}
+
+// Value 返回元素类型对应的切片，如 []int、[]string
+func (l *listElement) Value() any {
+	if l.isNaN() {
+		return nil
+	}
+	switch l.t {
+	case String:
+		return listValues(l.values, func(e Element) string { return e.Records() })
+	case Int:
+		return listValues(l.values, func(e Element) int { return e.Int() })
+	case Float:
+		return listValues(l.values, func(e Element) float64 { return e.Float() })
+	case Bool:
+		return listValues(l.values, func(e Element) bool { return e.Bool() })
+	default:
+		return listValues(l.values, func(e Element) any { return e.Value() })
+	}
+}
+
+func (l *listElement) dType() string {
+	return string(List(l.t))
+}
+
+func (l *listElement) copy() Element {
+	l2 := &listElement{t: l.t}
+	if l.values != nil {
+		l2.values = make([]Element, 0, len(l.values))
+		for _, value := range l.values {
+			l2.values = append(l2.values, value.copy())
+		}
+	}
+	return l2
+}
+
+func (l *listElement) isNaN() bool {
+	return l.values == nil
+}
+
+func (l *listElement) update(elem Element) {
+	l.Set(elem.Value())
+}
+
+func (l *listElement) String() string {
+	return l.Records()
+}
+
+func (l *listElement) Len() int {
+	return len(l.values)
+}
+
+func (l *listElement) Get(i int) Element {
+	if i < 0 || i >= len(l.values) {
+		return nil
+	}
+	return l.values[i]
+}
+
+// TrySet 设置值，value 可以为 JSON 数组字符串、空值字符串、切片或列表元素
+func (l *listElement) TrySet(value any) error {
+	switch val := value.(type) {
+	case string:
+		var items []any
+		if err := json.Unmarshal([]byte(val), &items); err != nil && !slices.Contains(naValues, val) {
+			return &ParseError{Index: -1, Value: val, Type: List(l.t)}
+		}
+	case *listElement:
+	default:
+		if value == nil || reflect.TypeOf(value).Kind() != reflect.Slice {
+			return &TypeMismatchError{Want: string(List(l.t)), Got: fmt.Sprintf("%T", value)}
+		}
+	}
+	l.Set(value)
+	return nil
+}
+
+// Contains 判断列表是否包含 value，value 先转换为列表元素类型再比较：整数列表可用任意整数类型或整数值的浮点数，
+// 浮点数列表可用整数，如 1 与 1.0 相等；无法转换的值及空值不与任何元素相等
+func (l *listElement) Contains(value any) bool {
+	target, ok := listKey(l.t, reflect.ValueOf(value))
+	if !ok {
+		return false
+	}
+	for _, v := range l.values {
+		if v.isNaN() {
+			continue
+		}
+		if key, _ := listKey(l.t, reflect.ValueOf(v.Value())); key == target {
+			return true
+		}
+	}
+	return false
+}
+
+// 将值转换为元素类型 t 的比较值，整数为 int64，浮点数为 float64，无法转换时返回 false
+func listKey(t Type, v reflect.Value) (any, bool) {
+	switch t {
+	case Int:
+		switch {
+		case v.CanInt():
+			return v.Int(), true
+		case v.CanUint() && v.Uint() <= math.MaxInt64:
+			return int64(v.Uint()), true
+		case v.CanFloat() && v.Float() == math.Trunc(v.Float()) && math.Abs(v.Float()) < math.MaxInt64:
+			return int64(v.Float()), true
+		}
+	case Float:
+		switch {
+		case v.CanInt():
+			return float64(v.Int()), true
+		case v.CanUint():
+			return float64(v.Uint()), true
+		case v.CanFloat() && !math.IsNaN(v.Float()):
+			return v.Float(), true
+		}
+	case String:
+		if v.Kind() == reflect.String {
+			return v.String(), true
+		}
+	case Bool:
+		if v.Kind() == reflect.Bool {
+			return v.Bool(), true
+		}
+	}
+	return nil, false
+}
+
+// 将列表元素转换为指定类型切片
+func listValues[T any](elements []Element, f func(Element) T) []T {
+	values := make([]T, 0, len(elements))
+	for _, element := range elements {
+		values = append(values, f(element))
+	}
+	return values
+}
+
+// Explode 将列表类型数据列展开，列表中每个元素生成一个新元素
+//
+//	返回展开后的数据列（类型为列表元素类型）及每个新元素对应的原索引，
+//	空列表或空值保留一个空值元素（布尔列表为 false），含有非列表元素时返回 *TypeMismatchError
+func (s *Series) Explode() (*Series, []int, error) {
+	if !s.t.IsList() {
+		return nil, nil, &UnsupportedOperationError{Op: "Explode", Type: s.t}
+	}
+	ns := &Series{
+		Name:     s.Name,
+		elements: make([]Element, 0, s.Len()),
+		t:        s.t.Elem(),
+	}
+	var indexes []int
+	for i, element := range s.elements {
+		// Append 不检查元素类型，列表类型数据集中可能含有其他类型的元素
+		l, ok := element.(*listElement)
+		if !ok {
+			return nil, nil, &TypeMismatchError{Want: string(s.t), Got: element.dType()}
+		}
+		if l.Len() == 0 {
+			x := NewElements(ns.t, 1)[0]
+			// 布尔值没有空值，保持 false
+			if ns.t != Bool {
+				x.Set("NaN")
+			}
+			ns.elements = append(ns.elements, x)
+			indexes = append(indexes, i)
+			continue
+		}
+		for _, value := range l.values {
+			ns.elements = append(ns.elements, value.copy())
+			indexes = append(indexes, i)
+		}
+	}
+	ns.indexes = slices.Clone(indexes)
+	return ns, indexes, nil
+}
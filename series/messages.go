@@ -0,0 +1,175 @@
+/**
+  Copyright (c) [2024] [JiangNan]
+  [pandas] is licensed under Mulan PSL v2.
+  You can use this software according to the terms and conditions of the Mulan PSL v2.
+  You may obtain a copy of Mulan PSL v2 at:
+           http://license.coscl.org.cn/MulanPSL2
+  THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND, EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT, MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+  See the Mulan PSL v2 for more details.
+*/
+
+package series
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+)
+
+// Lang 错误信息及输出使用的语言
+type Lang string
+
+const (
+	// Zh 中文，默认语言
+	Zh Lang = "zh"
+	// En 英文
+	En Lang = "en"
+)
+
+// 消息编号
+const (
+	MsgLengthMismatch       = "LengthMismatch"
+	MsgTypeMismatch         = "TypeMismatch"
+	MsgUnsupportedOperation = "UnsupportedOperation"
+	MsgParse                = "Parse"
+	MsgIndexOutOfRange      = "IndexOutOfRange"
+	MsgUnknownType          = "UnknownType"
+	MsgInvalidArgument      = "InvalidArgument"
+	MsgLengthMismatchDetail = "LengthMismatchDetail"
+	MsgTypeMismatchDetail   = "TypeMismatchDetail"
+	MsgUnsupportedOpDetail  = "UnsupportedOperationDetail"
+	MsgParseValue           = "ParseValue"
+	MsgParseElement         = "ParseElement"
+	MsgRegexNoGroup         = "RegexNoGroup"
+	MsgSeriesString         = "SeriesString"
+	MsgCorruptData          = "CorruptData"
+	MsgUnsupportedVersion   = "UnsupportedVersion"
+)
+
+var (
+	language atomic.Value
+	mu       sync.RWMutex
+	catalog  = map[Lang]map[string]string{
+		Zh: {
+			MsgLengthMismatch:       "长度不相等",
+			MsgTypeMismatch:         "数据类型不匹配",
+			MsgUnsupportedOperation: "不支持的操作",
+			MsgParse:                "数据转换失败",
+			MsgIndexOutOfRange:      "索引越界",
+			MsgUnknownType:          "未知数据类型",
+			MsgInvalidArgument:      "参数错误",
+			MsgLengthMismatchDetail: "长度不相等：期望 %d，实际 %d",
+			MsgTypeMismatchDetail:   "数据类型不匹配：期望 %s，实际 %s",
+			MsgUnsupportedOpDetail:  "%s 类型数据不支持操作 %s",
+			MsgParseValue:           "%q 不能转换为 %s",
+			MsgParseElement:         "第 %d 个元素 %q 不能转换为 %s",
+			MsgRegexNoGroup:         "正则表达式 %s 中没有分组",
+			MsgSeriesString:         "字段名：%s\n数 据：%v\n索 引：%v\n类 型：%s\n",
+			MsgCorruptData:          "数据已损坏",
+			MsgUnsupportedVersion:   "不支持的格式版本 %d",
+		},
+		En: {
+			MsgLengthMismatch:       "length mismatch",
+			MsgTypeMismatch:         "type mismatch",
+			MsgUnsupportedOperation: "unsupported operation",
+			MsgParse:                "parse error",
+			MsgIndexOutOfRange:      "index out of range",
+			MsgUnknownType:          "unknown type",
+			MsgInvalidArgument:      "invalid argument",
+			MsgLengthMismatchDetail: "length mismatch: want %d, got %d",
+			MsgTypeMismatchDetail:   "type mismatch: want %s, got %s",
+			MsgUnsupportedOpDetail:  "%s series does not support operation %s",
+			MsgParseValue:           "cannot convert %q to %s",
+			MsgParseElement:         "element %d: cannot convert %q to %s",
+			MsgRegexNoGroup:         "regular expression %s has no groups",
+			MsgSeriesString:         "Name: %s\nData: %v\nIndex: %v\nType: %s\n",
+			MsgCorruptData:          "corrupt data",
+			MsgUnsupportedVersion:   "unsupported format version %d",
+		},
+	}
+)
+
+// SetLanguage 设置包级默认语言，影响 series 及 dataframe 的错误信息与输出
+func SetLanguage(lang Lang) {
+	language.Store(lang)
+}
+
+// Language 返回包级默认语言，未设置时为 Zh
+func Language() Lang {
+	if lang, ok := language.Load().(Lang); ok {
+		return lang
+	}
+	return Zh
+}
+
+// RegisterMessages 添加或覆盖 lang 语言的消息，可用于补充新语言
+func RegisterMessages(lang Lang, messages map[string]string) {
+	mu.Lock()
+	defer mu.Unlock()
+	if catalog[lang] == nil {
+		catalog[lang] = make(map[string]string, len(messages))
+	}
+	for key, msg := range messages {
+		catalog[lang][key] = msg
+	}
+}
+
+// Message 按语言格式化编号为 key 的消息，lang 中不存在时使用中文，均不存在时使用 key
+func Message(lang Lang, key string, args ...any) string {
+	mu.RLock()
+	format, ok := catalog[lang][key]
+	if !ok {
+		format, ok = catalog[Zh][key]
+	}
+	mu.RUnlock()
+	if !ok {
+		format = key
+	}
+	if len(args) == 0 {
+		return format
+	}
+	return fmt.Sprintf(format, args...)
+}
+
+// Localizer 可按指定语言输出的错误
+type Localizer interface {
+	error
+	Localize(lang Lang) string
+}
+
+// Localize 按指定语言返回错误信息，err 不支持多语言时返回 err.Error()
+func Localize(err error, lang Lang) string {
+	if l, ok := err.(Localizer); ok {
+		return l.Localize(lang)
+	}
+	return err.Error()
+}
+
+// 按消息编号生成的错误
+type messageError struct {
+	wrapped error // errors.Is 匹配的错误类型，可为 nil
+	key     string
+	args    []any
+}
+
+// NewError 创建支持多语言的错误，wrapped 不为 nil 时可通过 errors.Is(err, wrapped) 判断，
+// 错误信息为 wrapped 的信息加上 key 对应的消息
+func NewError(wrapped error, key string, args ...any) error {
+	return &messageError{wrapped: wrapped, key: key, args: args}
+}
+
+func (e *messageError) Error() string {
+	return e.Localize(Language())
+}
+
+func (e *messageError) Localize(lang Lang) string {
+	msg := Message(lang, e.key, e.args...)
+	if e.wrapped != nil {
+		return Localize(e.wrapped, lang) + ": " + msg
+	}
+	return msg
+}
+
+func (e *messageError) Unwrap() error {
+	return e.wrapped
+}
@@ -0,0 +1,165 @@
+/**
+  Copyright (c) [2024] [JiangNan]
+  [pandas] is licensed under Mulan PSL v2.
+  You can use this software according to the terms and conditions of the Mulan PSL v2.
+  You may obtain a copy of Mulan PSL v2 at:
+           http://license.coscl.org.cn/MulanPSL2
+  THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND, EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT, MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+  See the Mulan PSL v2 for more details.
+*/
+
+package series
+
+import (
+	"golang.org/x/text/unicode/norm"
+	"strings"
+	"unicode"
+)
+
+// NormForm 文本规范化方式，可用 | 组合，按 NFKC、HalfWidth、Simplified、TrimSpace 的顺序执行
+type NormForm int
+
+const (
+	// HalfWidth 全角字母、数字、标点及全角空格转为半角
+	HalfWidth NormForm = 1 << iota
+	// NFKC Unicode NFKC 规范化
+	NFKC
+	// TrimSpace 去除首尾所有 Unicode 空白，包括不换行空格、全角空格及零宽字符
+	TrimSpace
+	// Simplified 繁体转简体，使用 T2S
+	Simplified
+)
+
+// CharMapper 文本转换器，用于繁简转换等按字或词替换的场景
+type CharMapper interface {
+	Map(s string) string
+}
+
+// RuneMap 逐字替换的 CharMapper
+type RuneMap map[rune]rune
+
+func (m RuneMap) Map(s string) string {
+	return strings.Map(func(r rune) rune {
+		if v, ok := m[r]; ok {
+			return v
+		}
+		return r
+	}, s)
+}
+
+// T2S 繁体转简体使用的转换器，默认只包含常用字，需要完整字表或按词转换时可替换为自定义实现
+var T2S CharMapper = defaultT2S
+
+// Normalize 按 form 规范化字符串
+func Normalize(s string, form NormForm) string {
+	if form&NFKC != 0 {
+		s = norm.NFKC.String(s)
+	}
+	if form&HalfWidth != 0 {
+		s = strings.Map(toHalfWidth, s)
+	}
+	if form&Simplified != 0 && T2S != nil {
+		s = T2S.Map(s)
+	}
+	if form&TrimSpace != 0 {
+		s = strings.TrimFunc(s, isSpace)
+	}
+	return s
+}
+
+// Normalize 按 form 规范化
+func (sm *StringMethods) Normalize(form NormForm) *Series {
+	return sm.apply(String, func(s string) any { return Normalize(s, form) })
+}
+
+// HalfWidth 全角转半角
+func (sm *StringMethods) HalfWidth() *Series {
+	return sm.Normalize(HalfWidth)
+}
+
+// NFKC Unicode NFKC 规范化
+func (sm *StringMethods) NFKC() *Series {
+	return sm.Normalize(NFKC)
+}
+
+// TrimSpace 去除首尾所有 Unicode 空白
+func (sm *StringMethods) TrimSpace() *Series {
+	return sm.Normalize(TrimSpace)
+}
+
+// Simplified 繁体转简体
+func (sm *StringMethods) Simplified() *Series {
+	return sm.Normalize(Simplified)
+}
+
+// MapChars 使用自定义转换器转换
+func (sm *StringMethods) MapChars(m CharMapper) *Series {
+	return sm.apply(String, func(s string) any { return m.Map(s) })
+}
+
+// 全角字符转半角，U+FF01~U+FF5E 对应 ASCII 可见字符，U+3000 为全角空格
+func toHalfWidth(r rune) rune {
+	switch {
+	case r == '\u3000':
+		return ' '
+	case r >= '\uff01' && r <= '\uff5e':
+		return r - 0xFEE0
+	default:
+		return r
+	}
+}
+
+// 空白字符，包括 unicode.IsSpace 及零宽字符
+func isSpace(r rune) bool {
+	return unicode.IsSpace(r) || r == '\u200b' || r == '\u200c' || r == '\u200d' || r == '\u2060' || r == '\ufeff'
+}
+
+// 常用繁体字与简体字对照
+var defaultT2S = RuneMap{
+	'這': '这', '個': '个', '們': '们', '來': '来', '說': '说', '為': '为', '國': '国', '學': '学', '時': '时', '會': '会',
+	'對': '对', '於': '于', '發': '发', '開': '开', '關': '关', '長': '长', '現': '现', '動': '动', '後': '后', '過': '过',
+	'還': '还', '進': '进', '經': '经', '電': '电', '話': '话', '號': '号', '碼': '码', '門': '门', '問': '问', '間': '间',
+	'聯': '联', '繫': '系', '係': '系', '區': '区', '縣': '县', '鄉': '乡', '鎮': '镇', '東': '东', '車': '车', '書': '书',
+	'買': '买', '賣': '卖', '價': '价', '錢': '钱', '銀': '银', '貨': '货', '幣': '币', '費': '费', '貿': '贸', '資': '资',
+	'產': '产', '業': '业', '務': '务', '員': '员', '單': '单', '據': '据', '訂': '订', '報': '报', '紙': '纸', '標': '标',
+	'準': '准', '質': '质', '實': '实', '際': '际', '總': '总', '計': '计', '帳': '账', '戶': '户', '點': '点', '數': '数',
+	'庫': '库', '頁': '页', '項': '项', '類': '类', '別': '别', '機': '机', '構': '构', '廠': '厂', '廣': '广', '場': '场',
+	'陽': '阳', '陰': '阴', '華': '华', '龍': '龙', '鳥': '鸟', '馬': '马', '魚': '鱼', '雞': '鸡', '麵': '面', '飯': '饭',
+	'館': '馆', '醫': '医', '藥': '药', '衛': '卫', '療': '疗', '師': '师', '認': '认', '證': '证', '識': '识', '讀': '读',
+	'寫': '写', '聽': '听', '見': '见', '覺': '觉', '親': '亲', '愛': '爱', '歡': '欢', '樂': '乐', '氣': '气', '風': '风',
+	'雲': '云', '熱': '热', '體': '体', '頭': '头', '髮': '发', '臉': '脸', '腦': '脑', '聲': '声', '異': '异', '樣': '样',
+	'應': '应', '該': '该', '讓': '让', '給': '给', '從': '从', '與': '与', '無': '无', '種': '种', '幾': '几', '萬': '万',
+	'億': '亿', '兩': '两', '雙': '双', '條': '条', '張': '张', '隻': '只', '歲': '岁', '歷': '历', '曆': '历', '週': '周',
+	'鐘': '钟', '錶': '表', '趙': '赵', '孫': '孙', '陳': '陈', '劉': '刘', '楊': '杨', '黃': '黄', '吳': '吴', '鄭': '郑',
+	'蔣': '蒋', '韓': '韩', '馮': '冯', '鄧': '邓', '許': '许', '蘇': '苏', '葉': '叶', '盧': '卢', '譚': '谭', '灣': '湾',
+	'臺': '台', '島': '岛', '滬': '沪', '廈': '厦', '寧': '宁', '漢': '汉', '齊': '齐', '盤': '盘', '網': '网', '絡': '络',
+	'線': '线', '紅': '红', '綠': '绿', '藍': '蓝', '顏': '颜', '紀': '纪', '約': '约', '級': '级', '組': '组', '織': '织',
+	'結': '结', '統': '统', '維': '维', '續': '续', '繼': '继', '設': '设', '備': '备', '議': '议', '論': '论', '調': '调',
+	'談': '谈', '請': '请', '護': '护', '險': '险', '債': '债', '稅': '税', '營': '营', '運': '运', '輸': '输', '達': '达',
+	'選': '选', '邊': '边', '遠': '远', '當': '当', '黨': '党', '滿': '满', '溫': '温', '濟': '济', '測': '测', '驗': '验',
+	'試': '试', '錯': '错', '誤': '误', '導': '导', '層': '层', '屬': '属', '歸': '归', '嚴': '严', '舊': '旧', '廢': '废',
+	'響': '响', '頂': '顶', '順': '顺', '預': '预', '領': '领', '題': '题', '顧': '顾', '額': '额', '飛': '飞', '鐵': '铁',
+	'鋼': '钢', '錄': '录', '鍵': '键', '鏡': '镜', '閱': '阅', '隊': '队', '陸': '陆', '隨': '随', '雜': '杂', '難': '难',
+	'雖': '虽', '靜': '静', '韋': '韦', '頻': '频', '顯': '显', '餘': '余', '鬥': '斗', '麼': '么', '齡': '龄', '優': '优',
+	'傳': '传', '償': '偿', '儲': '储', '兒': '儿', '內': '内', '冊': '册', '劃': '划', '劑': '剂', '勞': '劳', '勢': '势',
+	'勵': '励', '協': '协', '廳': '厅', '參': '参', '變': '变', '叢': '丛', '啟': '启', '團': '团', '圖': '图', '圓': '圆',
+	'園': '园', '圍': '围', '壓': '压', '壞': '坏', '壽': '寿', '夠': '够', '夢': '梦', '奮': '奋', '婦': '妇', '寶': '宝',
+	'寬': '宽', '專': '专', '將': '将', '尋': '寻', '屆': '届', '岡': '冈', '帶': '带', '幫': '帮', '彈': '弹', '徑': '径',
+	'復': '复', '徵': '征', '憶': '忆', '懷': '怀', '戰': '战', '擁': '拥', '擇': '择', '擊': '击', '擴': '扩', '攝': '摄',
+	'敗': '败', '敵': '敌', '斷': '断', '昇': '升', '晝': '昼', '暫': '暂', '暢': '畅', '權': '权', '棄': '弃', '樓': '楼',
+	'樹': '树', '橋': '桥', '檢': '检', '歐': '欧', '殺': '杀', '殼': '壳', '決': '决', '沒': '没', '況': '况', '沖': '冲',
+	'涼': '凉', '淚': '泪', '淨': '净', '淺': '浅', '減': '减', '湯': '汤', '溝': '沟', '滅': '灭', '漁': '渔', '潔': '洁',
+	'潤': '润', '澤': '泽', '燈': '灯', '爭': '争', '牆': '墙', '狀': '状', '獨': '独', '獲': '获', '環': '环', '畢': '毕',
+	'畫': '画', '盜': '盗', '監': '监', '盡': '尽', '確': '确', '禮': '礼', '禍': '祸', '稱': '称', '穩': '稳', '窮': '穷',
+	'競': '竞', '筆': '笔', '築': '筑', '範': '范', '節': '节', '簡': '简', '籃': '篮', '糧': '粮', '緊': '紧', '編': '编',
+	'練': '练', '縮': '缩', '績': '绩', '罰': '罚', '罷': '罢', '義': '义', '習': '习', '聖': '圣', '職': '职', '肅': '肃',
+	'脫': '脱', '膚': '肤', '興': '兴', '舉': '举', '艦': '舰', '藝': '艺', '蘭': '兰', '處': '处', '蟲': '虫', '術': '术',
+	'衝': '冲', '補': '补', '裝': '装', '製': '制', '複': '复', '規': '规', '視': '视', '覽': '览', '觀': '观', '觸': '触',
+	'訊': '讯', '記': '记', '評': '评', '詞': '词', '詢': '询', '詳': '详', '語': '语', '誠': '诚', '課': '课', '諮': '咨',
+	'講': '讲', '謝': '谢', '豐': '丰', '貝': '贝', '負': '负', '責': '责', '貴': '贵', '貸': '贷', '賀': '贺', '賓': '宾',
+	'賞': '赏', '賽': '赛', '購': '购', '贈': '赠', '趕': '赶', '躍': '跃', '軍': '军', '軟': '软', '較': '较', '載': '载',
+	'輕': '轻', '輛': '辆', '轉': '转', '農': '农', '遊': '游', '遲': '迟', '遺': '遗', '郵': '邮', '醜': '丑', '釋': '释',
+	'針': '针', '鈔': '钞', '鋪': '铺', '錦': '锦', '鍋': '锅', '閉': '闭', '閃': '闪', '閒': '闲', '陣': '阵', '離': '离',
+	'靈': '灵', '韻': '韵', '頒': '颁', '飲': '饮', '飽': '饱', '養': '养', '駕': '驾', '髒': '脏', '鬧': '闹', '魯': '鲁',
+	'鮮': '鲜', '鹽': '盐', '麗': '丽', '齒': '齿', '龜': '龟',
+}
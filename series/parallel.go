@@ -0,0 +1,86 @@
+/**
+  Copyright (c) [2024] [JiangNan]
+  [pandas] is licensed under Mulan PSL v2.
+  You can use this software according to the terms and conditions of the Mulan PSL v2.
+  You may obtain a copy of Mulan PSL v2 at:
+           http://license.coscl.org.cn/MulanPSL2
+  THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND, EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT, MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+  See the Mulan PSL v2 for more details.
+*/
+
+package series
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// Parallel 并行执行配置，数据按 ChunkSize 分块后由 Workers 个协程处理，结果顺序与串行执行相同
+type Parallel struct {
+	Workers   int // 并发协程数，小于等于 1 时串行执行
+	ChunkSize int // 每块元素数，小于等于 0 时为 DefaultChunkSize
+}
+
+// DefaultChunkSize 默认分块大小
+const DefaultChunkSize = 10000
+
+// ProgressStep 带进度回调的操作每处理 ProgressStep 行回调一次，结束时再回调一次
+const ProgressStep = 1000
+
+var parallel atomic.Value
+
+// SetParallel 设置包级并行执行配置，Series 的 Arithmetic、Filter、SetType、Records、Format 按行分块并行，
+// dataframe 的 Groups 按分组并行；DataFrame 的其他操作仍逐列执行，各列内按行分块并行。
+// 默认串行执行；Workers 大于 1 时 Format 的处理函数会被并发调用，需保证其并发安全
+func SetParallel(p Parallel) {
+	parallel.Store(p)
+}
+
+// CurrentParallel 返回包级并行执行配置
+func CurrentParallel() Parallel {
+	p, _ := parallel.Load().(Parallel)
+	return p
+}
+
+// For 将 [0, n) 分块执行 f，各块互不重叠，所有块执行完毕后返回
+func (p Parallel) For(n int, f func(start, end int)) {
+	size := p.ChunkSize
+	if size <= 0 {
+		size = DefaultChunkSize
+	}
+	if n <= 0 {
+		return
+	}
+	if p.Workers <= 1 || n <= size {
+		f(0, n)
+		return
+	}
+
+	chunks := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < min(p.Workers, (n+size-1)/size); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for start := range chunks {
+				f(start, min(start+size, n))
+			}
+		}()
+	}
+	for start := 0; start < n; start += size {
+		chunks <- start
+	}
+	close(chunks)
+	wg.Wait()
+}
+
+// 按包级配置并行生成 n 个结果
+func parallelMap[T any](n int, f func(i int) T) []T {
+	x := make([]T, n)
+	CurrentParallel().For(n, func(start, end int) {
+		for i := start; i < end; i++ {
+			x[i] = f(i)
+		}
+	})
+	return x
+}
@@ -0,0 +1,238 @@
+/**
+  Copyright (c) [2024] [JiangNan]
+  [pandas] is licensed under Mulan PSL v2.
+  You can use this software according to the terms and conditions of the Mulan PSL v2.
+  You may obtain a copy of Mulan PSL v2 at:
+           http://license.coscl.org.cn/MulanPSL2
+  THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND, EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT, MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+  See the Mulan PSL v2 for more details.
+*/
+
+package series
+
+import (
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// NumberParser 数值解析器，用于解析报表中带格式的数字，如 "1,234"、"12.5%"、"¥300"、"(50)"、"3.2万"
+type NumberParser struct {
+	GroupSeparator   rune               // 千分位分隔符，为 0 时不处理
+	DecimalSeparator rune               // 小数点，为 0 时使用 '.'
+	Currency         []string           // 货币符号，出现在数字前后时去除
+	Percent          bool               // 是否解析百分号 % 及千分号 ‰
+	Parentheses      bool               // 是否将括号包裹的数字解析为负数
+	Units            map[string]float64 // 数量级单位后缀及倍数，如 "万": 1e4
+}
+
+// NewNumberParser 创建启用全部规则的数值解析器
+func NewNumberParser() *NumberParser {
+	return &NumberParser{
+		GroupSeparator:   ',',
+		DecimalSeparator: '.',
+		Currency:         []string{"¥", "￥", "$", "€", "£", "HK$", "US$", "RMB", "CNY", "USD", "元"},
+		Percent:          true,
+		Parentheses:      true,
+		Units:            map[string]float64{"千": 1e3, "万": 1e4, "十万": 1e5, "百万": 1e6, "千万": 1e7, "亿": 1e8, "万亿": 1e12},
+	}
+}
+
+// DefaultNumberParser LoadRecords 等未指定解析器时使用的默认数值解析器
+var DefaultNumberParser = NewNumberParser()
+
+// ParseFloat 解析浮点数
+func (p *NumberParser) ParseFloat(s string) (float64, error) {
+	num, scale, ok := p.clean(s)
+	if !ok {
+		return math.NaN(), &ParseError{Index: -1, Value: s, Type: Float}
+	}
+	f, err := strconv.ParseFloat(num, 64)
+	if err != nil {
+		return math.NaN(), &ParseError{Index: -1, Value: s, Type: Float}
+	}
+	return f * scale, nil
+}
+
+// ParseInt 解析整数，带单位或百分号的结果必须为整数
+func (p *NumberParser) ParseInt(s string) (int, error) {
+	num, scale, ok := p.clean(s)
+	if !ok {
+		return math.MinInt, &ParseError{Index: -1, Value: s, Type: Int}
+	}
+	if scale == 1 {
+		if i, err := strconv.Atoi(num); err == nil {
+			return i, nil
+		}
+	}
+	f, err := strconv.ParseFloat(num, 64)
+	if f *= scale; err != nil || f != math.Trunc(f) || math.Abs(f) >= math.MaxInt64 {
+		return math.MinInt, &ParseError{Index: -1, Value: s, Type: Int}
+	}
+	return int(f), nil
+}
+
+// 去除格式，返回可被 strconv 解析的数字及倍数，s 不是有效的数字时 ok 为 false
+func (p *NumberParser) clean(s string) (num string, scale float64, ok bool) {
+	num, scale, negative := strings.TrimSpace(s), 1.0, false
+	if p.Parentheses {
+		for _, pair := range [][2]string{{"(", ")"}, {"（", "）"}} {
+			if strings.HasPrefix(num, pair[0]) && strings.HasSuffix(num, pair[1]) {
+				num, negative = strings.TrimSpace(num[len(pair[0]):len(num)-len(pair[1])]), true
+				break
+			}
+		}
+	}
+	num = p.trimCurrency(num)
+	if strings.HasPrefix(num, "-") || strings.HasPrefix(num, "+") {
+		negative = negative != (num[0] == '-')
+		num = p.trimCurrency(num[1:])
+	}
+	if p.Percent {
+		if strings.HasSuffix(num, "%") {
+			num, scale = strings.TrimSuffix(num, "%"), 0.01
+		} else if strings.HasSuffix(num, "‰") {
+			num, scale = strings.TrimSuffix(num, "‰"), 0.001
+		}
+	}
+	if scale == 1 {
+		// 优先匹配较长的单位，如 "百万" 优先于 "万"
+		unit := ""
+		for u := range p.Units {
+			if strings.HasSuffix(num, u) && len(u) > len(unit) {
+				unit = u
+			}
+		}
+		if unit != "" {
+			num, scale = p.trimCurrency(strings.TrimSuffix(num, unit)), p.Units[unit]
+		}
+	}
+	if p.GroupSeparator != 0 {
+		num = strings.ReplaceAll(num, string(p.GroupSeparator), "")
+	}
+	if p.DecimalSeparator != 0 && p.DecimalSeparator != '.' {
+		num = strings.ReplaceAll(num, string(p.DecimalSeparator), ".")
+	}
+	if lower := strings.ToLower(num); num == "" || strings.ContainsAny(num[:1], "+-") ||
+		strings.Contains(lower, "nan") || strings.Contains(lower, "inf") {
+		return "", scale, false
+	}
+	if negative {
+		num = "-" + num
+	}
+	return num, scale, true
+}
+
+// 去除首尾货币符号及空白
+func (p *NumberParser) trimCurrency(s string) string {
+	for _, c := range p.Currency {
+		s = strings.TrimSpace(strings.TrimSuffix(strings.TrimPrefix(s, c), c))
+	}
+	return s
+}
+
+// ParseError 字符串转换为指定类型失败的信息
+type ParseError struct {
+	Index int    // 元素索引，不是数据列中的元素时为 -1
+	Value string // 原始值
+	Type  Type   // 目标类型
+}
+
+func (e *ParseError) Error() string {
+	return e.Localize(Language())
+}
+
+func (e *ParseError) Localize(lang Lang) string {
+	if e.Index < 0 {
+		return Message(lang, MsgParseValue, e.Value, e.Type)
+	}
+	return Message(lang, MsgParseElement, e.Index, e.Value, e.Type)
+}
+
+func (e *ParseError) Is(target error) bool {
+	return target == ErrParse
+}
+
+// LoadOptions 用字符串切片创建数据列的配置
+type LoadOptions struct {
+	Parser    *NumberParser // 数值解析器，为 nil 时使用 DefaultNumberParser
+	NAValues  []string      // 除默认空值标记 "", "NaN", "nan", "null", "Null" 外，额外视为空值的字符串
+	KeepEmpty bool          // 空字符串作为有效值保留，仅对 String 类型生效
+	Strict    bool          // 严格模式，存在转换失败的元素时不生成数据列
+}
+
+// 默认空值标记
+var naValues = []string{"", "NaN", "nan", "null", "Null"}
+
+// 判断 value 加载为 t 类型时是否为空值
+func (o LoadOptions) isNA(value string, t Type) bool {
+	if slices.Contains(o.NAValues, value) {
+		return true
+	}
+	if value == "" && o.KeepEmpty && t == String {
+		return false
+	}
+	return slices.Contains(naValues, value)
+}
+
+func (o LoadOptions) parser() *NumberParser {
+	if o.Parser == nil {
+		return DefaultNumberParser
+	}
+	return o.Parser
+}
+
+// LoadRecordsWith 按配置用字符串切片创建指定类型数据列，并返回转换失败的元素，失败元素置为空值（Bool 置为 false），
+// 严格模式下存在转换失败的元素时返回的数据列为 nil。Bool 的取值同 strconv.ParseBool，如 "yes"、"否" 转换失败
+//
+//	values: 数据切片
+//	t: 数据类型
+//	name: 数据列名称
+//	opts: 加载配置
+func LoadRecordsWith(values []string, t Type, name string, opts LoadOptions) (*Series, []*ParseError) {
+	parser := opts.parser()
+	ns := &Series{
+		Name:     name,
+		elements: NewElements(t, len(values)),
+		t:        t,
+	}
+	var errs []*ParseError
+	for i, value := range values {
+		element := ns.elements[i]
+		var failed bool
+		switch {
+		case opts.isNA(value, t):
+			// 布尔值没有空值，保持 false
+			if t != Bool {
+				element.Set("NaN")
+			}
+		case t == String && value == "":
+			*element.(*stringElement) = ""
+		case t == Int:
+			v, err := parser.ParseInt(value)
+			element.Set(v)
+			failed = err != nil
+		case t == Float:
+			v, err := parser.ParseFloat(value)
+			element.Set(v)
+			failed = err != nil
+		case t == Bool:
+			// 取值同 strconv.ParseBool，其他字符串转换失败
+			v, err := strconv.ParseBool(value)
+			element.Set(v)
+			failed = err != nil
+		default:
+			element.Set(value)
+			failed = element.isNaN()
+		}
+		if failed {
+			errs = append(errs, &ParseError{Index: i, Value: value, Type: t})
+		}
+	}
+	if opts.Strict && errs != nil {
+		return nil, errs
+	}
+	ns.InitIndex()
+	return ns, errs
+}
@@ -12,13 +12,15 @@ package series
 
 import (
 	"cmp"
+	"context"
 	"fmt"
-	"gitee.com/jn-qq/go-tools/data"
 	"github.com/shopspring/decimal"
 	"math"
 	"reflect"
+	"regexp"
 	"slices"
 	"strings"
+	"time"
 )
 
 type Series struct {
@@ -26,6 +28,8 @@ type Series struct {
 	elements []Element
 	t        Type
 	indexes  []int
+	shared   bool // elements 中的元素可能与其他数据列共享，写入前需复制
+	exposed  bool // 元素已由 Elements、Element 或 At 交给调用方，调用方可能随时修改，不能再共享
 }
 
 type Type string
@@ -35,14 +39,17 @@ const (
 	Int    Type = "int"
 	Float  Type = "float64"
 	Bool   Type = "bool"
+	// Datetime 日期时间，元素值为 time.Time，零值表示空值。字符串可为 RFC 3339、"2006-01-02 15:04:05"、"2006-01-02"、
+	// "2006/01/02" 等格式，不含时区时按 UTC 解析；整数、浮点数视为 Unix 秒
+	Datetime Type = "datetime"
 )
 
 // NewSeries 创建数据列
 //
 //	values: 数据切片
-//	dType: 数据类型，可选String、Int、float64、Bool
+//	dType: 数据类型，可选String、Int、float64、Bool、Datetime（values 为 []time.Time）及 List(T)
 //	name: 数据列名称
-func NewSeries[S interface{ ~[]E }, E int | float64 | string | bool](values S, dType Type, name string) (*Series, error) {
+func NewSeries[S interface{ ~[]E }, E int | float64 | string | bool | time.Time | []int | []float64 | []string | []bool](values S, dType Type, name string) (*Series, error) {
 	s := &Series{
 		Name:     name,
 		t:        dType,
@@ -51,8 +58,8 @@ func NewSeries[S interface{ ~[]E }, E int | float64 | string | bool](values S, d
 
 	if values == nil {
 		return s, nil
-	} else if reflect.TypeOf(values).String() != fmt.Sprintf("[]%s", dType) {
-		return nil, fmt.Errorf("输入切片与指定数据类型不匹配")
+	} else if reflect.TypeOf(values).String() != "[]"+dType.goType() {
+		return nil, &TypeMismatchError{Want: "[]" + dType.goType(), Got: reflect.TypeOf(values).String()}
 	} else {
 		if err := s.Append(values); err != nil {
 			return nil, err
@@ -66,16 +73,10 @@ func NewSeries[S interface{ ~[]E }, E int | float64 | string | bool](values S, d
 //	values: 数据切片
 //	dType: 数据类型，可选String、Int、float64、Bool
 //	name: 数据列名称
+//
+//	数值类型使用 DefaultNumberParser 解析，转换失败的元素置为空值，需要获取失败信息时使用 LoadRecordsWith
 func LoadRecords(values []string, t Type, name string) *Series {
-	ns := &Series{
-		Name:     name,
-		elements: NewElements(t, len(values)),
-		t:        t,
-	}
-	for i, value := range values {
-		ns.Element(i).Set(value)
-	}
-	ns.InitIndex()
+	ns, _ := LoadRecordsWith(values, t, name, LoadOptions{})
 	return ns
 }
 
@@ -90,9 +91,10 @@ func (s *Series) InitIndex() {
 // Append 向数据集后添加元素,可以为单个元素或元素切片,最好保证数据类型一致
 //
 //	values：Series、int []int、string []string ...
+//	列表类型数据集中，[]int 等一维切片作为单个元素添加，[][]int 等二维切片作为多个元素添加
 func (s *Series) Append(values interface{}) error {
-	if reflect.TypeOf(values).Kind() == reflect.Slice {
-		value := reflect.ValueOf(values)
+	if value := reflect.ValueOf(values); value.Kind() == reflect.Slice &&
+		(!s.t.IsList() || value.Type().Elem().Kind() == reflect.Slice) {
 		for i := 0; i < value.Len(); i++ {
 			if err := s.Append(value.Index(i).Interface()); err != nil {
 				return err
@@ -113,14 +115,22 @@ func (s *Series) Append(values interface{}) error {
 		case bool:
 			x = new(boolElement)
 			x.Set(v)
+		case time.Time:
+			x = new(timeElement)
+			x.Set(v)
+		case []int, []float64, []string, []bool, []any:
+			x = &listElement{t: s.t.Elem()}
+			x.Set(v)
 		case Element:
 			x = v
 		case Series:
-			s.elements = append(s.elements, v.elements...)
+			for _, element := range v.elements {
+				s.elements = append(s.elements, element.copy())
+			}
 			s.InitIndex()
 			return nil
 		default:
-			return fmt.Errorf("不支持的数据类型 %v, %s", v, reflect.TypeOf(v))
+			return &TypeMismatchError{Want: string(s.t), Got: fmt.Sprintf("%T", v)}
 		}
 		s.elements = append(s.elements, x.copy())
 	}
@@ -128,13 +138,19 @@ func (s *Series) Append(values interface{}) error {
 	return nil
 }
 
-// Drop 删除指定索引的元素
+// Drop 删除指定索引的元素，越界的索引忽略
 func (s *Series) Drop(indexes ...int) *Series {
-	slices.SortFunc(slices.Compact(indexes), func(a, b int) int {
+	indexes = slices.Clone(indexes)
+	slices.SortFunc(indexes, func(a, b int) int {
 		return cmp.Compare(b, a)
 	})
+	indexes = slices.Compact(indexes)
 	ns := s.Copy()
+	ns.own()
 	for _, index := range indexes {
+		if index < 0 || index >= ns.Len() {
+			continue
+		}
 		ns.elements = slices.Delete(ns.elements, index, index+1)
 		ns.indexes = slices.Delete(ns.indexes, index, index+1)
 	}
@@ -144,58 +160,119 @@ func (s *Series) Drop(indexes ...int) *Series {
 // Concat 将新数据集加原数据集后,如果类型不同，以原数据集为准
 func (s *Series) Concat(x Series) error {
 	if x.t != s.t {
-		fmt.Printf("两个数据类型不同，正在尝试转换...")
 		if err := x.SetType(s.t); err != nil {
 			return err
 		}
-		fmt.Println(" done")
 	}
 
-	s.elements = slices.Concat(s.elements, x.elements)
+	for _, element := range x.elements {
+		s.elements = append(s.elements, element.copy())
+	}
 
 	s.InitIndex()
 
 	return nil
 }
 
-// SubSet 保留对应索引的元素
+// SubSet 保留对应索引的元素，与原数据集共享元素直至其中一方写入
 func (s *Series) SubSet(indexes ...int) (*Series, error) {
-	if slices.Max(indexes) >= len(s.elements) {
-		return nil, fmt.Errorf("index out of range")
-	}
 	var elements []Element
 	for _, index := range indexes {
-		elements = append(elements, s.elements[index].copy())
+		if index < 0 || index >= len(s.elements) {
+			return nil, NewError(ErrIndexOutOfRange, "%d", index)
+		}
+		elements = append(elements, s.elements[index])
 	}
 
 	newSeries := Series{
 		Name:     s.Name,
 		elements: elements,
 		t:        s.t,
-		indexes:  indexes,
+		indexes:  slices.Clone(indexes),
+	}
+	if s.exposed {
+		newSeries.shared = true
+		newSeries.own()
+	} else {
+		s.share()
+		newSeries.shared = true
 	}
 
 	return &newSeries, nil
 }
 
-// Elements 返回数据集元素对象切片
+// Elements 返回数据集元素对象切片，修改其中的元素会修改数据集，不会影响此前或此后 Copy、SubSet 得到的数据列。
+// 会写入数据集，不能与其他操作并发执行
 func (s *Series) Elements() []Element {
+	s.expose()
 	return s.elements
 }
 
-// Element 指定索引元素
+// Element 指定索引元素，索引越界时 panic，不确定索引是否有效时使用 At。
+// 修改返回的元素会修改数据集，同 Elements
 func (s *Series) Element(i int) Element {
+	s.expose()
 	return s.elements[i]
 }
 
-// Format 批量处理数据集
+// At 指定索引元素，索引越界时返回 ErrIndexOutOfRange。
+// 修改返回的元素会修改数据集，同 Elements
+func (s *Series) At(i int) (Element, error) {
+	if i < 0 || i >= len(s.elements) {
+		return nil, NewError(ErrIndexOutOfRange, "%d", i)
+	}
+	s.expose()
+	return s.elements[i], nil
+}
+
+// SetAt 设置指定索引元素的值，同 Element.Set，索引越界时返回 ErrIndexOutOfRange。
+// 不交出元素，之后的 Copy、SubSet 仍可共享元素
+func (s *Series) SetAt(i int, value any) error {
+	if i < 0 || i >= len(s.elements) {
+		return NewError(ErrIndexOutOfRange, "%d", i)
+	}
+	s.own()
+	s.elements[i].Set(value)
+	return nil
+}
+
+// 将元素交给调用方前调用，之后的 Copy、SubSet 复制元素而不再共享
+func (s *Series) expose() {
+	s.own()
+	s.exposed = true
+}
+
+// 标记元素与其他数据列共享，已标记时不写入
+func (s *Series) share() {
+	if !s.shared {
+		s.shared = true
+	}
+}
+
+// 写入前调用，元素与其他数据列共享时复制一份，使修改不影响其他数据列
+func (s *Series) own() {
+	if !s.shared {
+		return
+	}
+	elements := make([]Element, len(s.elements))
+	for i, element := range s.elements {
+		elements[i] = element.copy()
+	}
+	s.elements = elements
+	s.shared = false
+}
+
+// Format 批量处理数据集，并行执行时 f 会被并发调用
 //
 //	index: 元素索引
 //	elem: 元素对象
 func (s *Series) Format(f func(index int, elem Element) Element) {
-	for i, element := range s.elements {
-		s.elements[i].update(f(i, element))
-	}
+	s.own()
+	CurrentParallel().For(s.Len(), func(start, end int) {
+		for i := start; i < end; i++ {
+			s.elements[i].update(f(i, s.elements[i]))
+		}
+	})
 }
 
 // SetType 改变数据集类型
@@ -211,22 +288,26 @@ func (s *Series) SetType(t Type) error {
 		indexes:  s.indexes,
 	}
 
-	var values any
 	switch t {
-	case String:
-		values = s.Records()
-	case Int:
-		values = s.Int()
-	case Float:
-		values = s.Float()
-	case Bool:
-		values = s.Bool()
+	case String, Int, Float, Bool, Datetime:
 	default:
-		return fmt.Errorf("未知数据类型！")
+		if !t.IsList() {
+			return NewError(ErrUnknownType, "%s", t)
+		}
 	}
 
-	if err := newSeries.Append(values); err != nil {
-		return err
+	newSeries.elements = NewElements(t, s.Len())
+	CurrentParallel().For(s.Len(), func(start, end int) {
+		for i := start; i < end; i++ {
+			if t.IsList() {
+				newSeries.elements[i].Set(s.elements[i].Records())
+			} else {
+				newSeries.elements[i].update(s.elements[i])
+			}
+		}
+	})
+	if !t.IsList() {
+		newSeries.InitIndex()
 	}
 
 	*s = newSeries
@@ -234,11 +315,28 @@ func (s *Series) SetType(t Type) error {
 	return nil
 }
 
-// SortIndex 生成升序、降序索引变化
+// SortIndex 生成升序、降序索引变化，布尔值按 false 在前排序，列表按字符串排序
 func (s *Series) SortIndex(reverse bool) []int {
-	var elements = s.Copy().Elements()
+	indexes, _ := s.SortIndexContext(context.Background(), reverse, nil)
+	return indexes
+}
+
+// SortIndexContext 同 SortIndex，ctx 取消时返回 ctx.Err()
+//
+//	progress: 进度回调，参数为已排序的元素数，可为 nil
+func (s *Series) SortIndexContext(ctx context.Context, reverse bool, progress func(rows int)) ([]int, error) {
+	// 只交换元素的位置，不修改元素
+	var elements = slices.Clone(s.elements)
 	var indexes = slices.Clone(s.indexes)
 	for i := 0; i < s.Len(); i++ {
+		if i%ProgressStep == 0 && i > 0 {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+			if progress != nil {
+				progress(i)
+			}
+		}
 		ele := slices.MinFunc(elements[i:], func(a, b Element) int {
 			if reverse {
 				a, b = b, a
@@ -250,8 +348,12 @@ func (s *Series) SortIndex(reverse bool) []int {
 				return cmp.Compare(a.Int(), b.Int())
 			case Float:
 				return cmp.Compare(a.Float(), b.Float())
+			case Bool:
+				return cmp.Compare(a.Int(), b.Int())
+			case Datetime:
+				return timeOf(a).Compare(timeOf(b))
 			default:
-				panic("bool 不支持排序")
+				return cmp.Compare(a.Records(), b.Records())
 			}
 		})
 
@@ -259,13 +361,21 @@ func (s *Series) SortIndex(reverse bool) []int {
 		elements[i], elements[j] = elements[j], elements[i]
 		indexes[i], indexes[j] = indexes[j], indexes[i]
 	}
+	if progress != nil {
+		progress(s.Len())
+	}
 
-	return indexes
+	return indexes, nil
 }
 
 // 自定义输出
 func (s *Series) String() string {
-	return fmt.Sprintf("字段名：%s\n数 据：%v\n索 引：%v\n类 型：%s\n", s.Name, s.Records(), s.indexes, s.t)
+	return s.StringIn(Language())
+}
+
+// StringIn 按指定语言输出
+func (s *Series) StringIn(lang Lang) string {
+	return Message(lang, MsgSeriesString, s.Name, s.Records(), s.indexes, s.t)
 }
 
 // Len 返回数据集大小
@@ -288,55 +398,55 @@ func (s *Series) HasNaN() bool {
 	return false
 }
 
-// Copy 复制
+// Copy 复制，与原数据集共享元素直至其中一方写入，两者的修改互不影响。
+// 原数据集已共享时 Copy 不写入原数据集，可与其他只读操作并发执行；
+// 元素已由 Elements、Element 或 At 取出时立即复制元素，避免通过取出的元素修改副本
 func (s *Series) Copy() *Series {
-	ns := Series{
+	ns := &Series{
 		Name:     s.Name,
-		elements: NewElements(s.t, s.Len()),
+		elements: slices.Clip(s.elements),
 		t:        s.t,
-		indexes:  s.indexes,
+		indexes:  slices.Clone(s.indexes),
+		shared:   true,
 	}
-	for i, element := range s.elements {
-		ns.elements[i].update(element)
+	if s.exposed {
+		ns.own()
+	} else {
+		s.share()
 	}
-
-	return &ns
+	return ns
 }
 
 // Records 将数据集中的元素作为字符串返回
 func (s *Series) Records() []string {
-	var x []string
-	for _, element := range s.elements {
-		x = append(x, element.Records())
+	if s.Len() == 0 {
+		return nil
 	}
-	return x
+	return parallelMap(s.Len(), func(i int) string { return s.elements[i].Records() })
 }
 
 // Float 将数据集中的元素作为浮点数返回，如果数据转换失败自动设为 math.NaN()
 func (s *Series) Float() []float64 {
-	var x []float64
-	for _, element := range s.elements {
-		x = append(x, element.Float())
+	if s.Len() == 0 {
+		return nil
 	}
-	return x
+	return parallelMap(s.Len(), func(i int) float64 { return s.elements[i].Float() })
 }
 
 // Int 将数据集中的元素作为整数返回，如果数据转换失败自动设为 math.MinInt()
 func (s *Series) Int() []int {
-	var x []int
-	for _, element := range s.elements {
-		x = append(x, element.Int())
+	if s.Len() == 0 {
+		return nil
 	}
-	return x
+	return parallelMap(s.Len(), func(i int) int { return s.elements[i].Int() })
 }
 
 // Bool 将数据集中的元素作为浮布尔值返回
 func (s *Series) Bool() []bool {
-	var x []bool
-	for _, element := range s.elements {
-		x = append(x, element.Bool())
+	if s.Len() == 0 {
+		return nil
 	}
-	return x
+	return parallelMap(s.Len(), func(i int) bool { return s.elements[i].Bool() })
 }
 
 func (s *Series) Any() []any {
@@ -381,6 +491,8 @@ const (
 	In
 	// NotIn 不在...列表里
 	NotIn
+	// Regex 匹配正则表达式
+	Regex
 )
 
 const (
@@ -396,92 +508,140 @@ const (
 	Remainder
 )
 
+func (o RelationalOperator) String() string {
+	names := []string{"Equal", "NotEqual", "LessThan", "LessOrEqual", "GreaterThan", "GreaterOrEqual",
+		"Contains", "StartsWith", "EndsWith", "In", "NotIn", "Regex"}
+	if int(o) < 0 || int(o) >= len(names) {
+		return fmt.Sprintf("RelationalOperator(%d)", int(o))
+	}
+	return names[o]
+}
+
+func (o ArithmeticOperator) String() string {
+	names := []string{"Addition", "Subtraction", "Multiplication", "Division", "Remainder"}
+	if int(o) < 0 || int(o) >= len(names) {
+		return fmt.Sprintf("ArithmeticOperator(%d)", int(o))
+	}
+	return names[o]
+}
+
 // Filter 过滤数据集
 func (s *Series) Filter(operator RelationalOperator, values any) (*Series, error) {
 	// 判断待比对数据类型，是否与原数据集相同
-	vT := reflect.TypeOf(values).String()
-	if !(vT == fmt.Sprintf("[]%s", s.t) || vT == string(s.t)) {
-		return nil, fmt.Errorf("输入数据类型与数据集类型不同")
+	vT := fmt.Sprintf("%T", values)
+	if s.t.IsList() {
+		// 列表类型仅支持判断是否包含某个元素
+		if operator != Contains {
+			return nil, &UnsupportedOperationError{Op: operator.String(), Type: s.t}
+		} else if vT != string(s.t.Elem()) {
+			return nil, &TypeMismatchError{Want: string(s.t.Elem()), Got: vT}
+		}
+	} else if !(vT == "[]"+s.t.goType() || vT == s.t.goType()) {
+		return nil, &TypeMismatchError{Want: s.t.goType(), Got: vT}
 	}
 
 	// 判断对应数据类型的方法是否合法
+	var operators []RelationalOperator
 	switch s.t {
 	case String:
-		if !slices.Contains([]RelationalOperator{0, 1, 6, 7, 8, 9, 10}, operator) {
-			return nil, fmt.Errorf("string 类型数据无法执行操作%d", operator)
-		}
-
-	case Int:
-		if !slices.Contains([]RelationalOperator{0, 1, 2, 3, 4, 5, 9, 10}, operator) {
-			return nil, fmt.Errorf("int 类型数据无法执行操作%d", operator)
-		}
-	case Float:
-		if !slices.Contains([]RelationalOperator{0, 1, 2, 3, 4, 5, 9, 10}, operator) {
-			return nil, fmt.Errorf("float64 类型数据无法执行操作%d", operator)
-		}
+		operators = []RelationalOperator{Equal, NotEqual, Contains, StartsWith, EndsWith, In, NotIn, Regex}
+	case Int, Float, Datetime:
+		operators = []RelationalOperator{Equal, NotEqual, LessThan, LessOrEqual, GreaterThan, GreaterOrEqual, In, NotIn}
 	case Bool:
-		if !slices.Contains([]RelationalOperator{0, 1}, operator) {
-			return nil, fmt.Errorf("bool 类型数据无法执行操作%d", operator)
+		operators = []RelationalOperator{Equal, NotEqual}
+	}
+	if operators != nil && !slices.Contains(operators, operator) {
+		return nil, &UnsupportedOperationError{Op: operator.String(), Type: s.t}
+	}
+
+	// In / NotIn 需要输入切片，其余操作需要输入单个值
+	if !s.t.IsList() && (operator == In || operator == NotIn) != (vT == "[]"+s.t.goType()) {
+		if operator == In || operator == NotIn {
+			return nil, &TypeMismatchError{Want: "[]" + s.t.goType(), Got: vT}
 		}
+		return nil, &TypeMismatchError{Want: s.t.goType(), Got: vT}
 	}
 
-	if (operator == 9 || operator == 10) && reflect.TypeOf(values).Kind() != reflect.Slice {
-		return nil, fmt.Errorf("in / NotIn 需要输入切片作为参数")
+	var re *regexp.Regexp
+	if operator == Regex {
+		pattern, ok := values.(string)
+		if !ok {
+			return nil, &TypeMismatchError{Want: string(String), Got: vT}
+		}
+		var err error
+		if re, err = regexp.Compile(pattern); err != nil {
+			return nil, err
+		}
 	}
 
-	_, indexes := data.Filter(s.elements, func(element Element) bool {
+	matched := parallelMap(s.Len(), func(i int) bool {
+		element := s.elements[i]
+		if s.t == Datetime {
+			return matchTime(operator, element, values)
+		}
 		switch operator {
-		case 0:
+		case Equal:
 			return element.Value() == values
-		case 1:
+		case NotEqual:
 			return element.Value() != values
-		case 2, 3, 4, 5:
+		case LessThan, LessOrEqual, GreaterThan, GreaterOrEqual:
 			if math.IsNaN(element.Float()) {
 				return false
 			}
 			if reflect.TypeOf(values).Kind() == reflect.Int {
 				switch operator {
-				case 2:
+				case LessThan:
 					return element.Int() < values.(int)
-				case 3:
+				case LessOrEqual:
 					return element.Int() <= values.(int)
-				case 4:
+				case GreaterThan:
 					return element.Int() > values.(int)
-				case 5:
+				case GreaterOrEqual:
 					return element.Int() >= values.(int)
 				}
 			} else {
 				switch operator {
-				case 2:
+				case LessThan:
 					return element.Float() < values.(float64)
-				case 3:
+				case LessOrEqual:
 					return element.Float() <= values.(float64)
-				case 4:
+				case GreaterThan:
 					return element.Float() > values.(float64)
-				case 5:
+				case GreaterOrEqual:
 					return element.Float() >= values.(float64)
 				}
 			}
-		case 6:
+		case Contains:
+			if l, ok := element.(ListElement); ok {
+				return l.Contains(values)
+			}
 			return strings.Contains(element.Records(), values.(string))
-		case 7:
+		case StartsWith:
 			return strings.HasPrefix(element.Records(), values.(string))
-		case 8:
+		case EndsWith:
 			return strings.HasSuffix(element.Records(), values.(string))
-		case 9, 10:
+		case In, NotIn:
 			var newValues []any
 			v := reflect.ValueOf(values)
 			for i := 0; i < v.Len(); i++ {
 				newValues = append(newValues, v.Index(i).Interface())
 			}
-			if operator == 9 {
+			if operator == In {
 				return slices.Contains(newValues, element.Value())
 			} else {
 				return !slices.Contains(newValues, element.Value())
 			}
+		case Regex:
+			return !element.isNaN() && re.MatchString(element.Records())
 		}
 		return false
 	})
+	var indexes []int
+	for i, ok := range matched {
+		if ok {
+			indexes = append(indexes, i)
+		}
+	}
 
 	if subSet, err := s.SubSet(indexes...); err != nil {
 		return nil, err
@@ -490,6 +650,42 @@ func (s *Series) Filter(operator RelationalOperator, values any) (*Series, error
 	}
 }
 
+// 按十进制精度计算，除数为 0 时返回 math.NaN()，空值及无穷大按浮点数规则计算
+func arithmetic(operator ArithmeticOperator, a, b float64) float64 {
+	if (operator == Division || operator == Remainder) && b == 0 {
+		return math.NaN()
+	}
+	if math.IsNaN(a) || math.IsNaN(b) || math.IsInf(a, 0) || math.IsInf(b, 0) {
+		switch operator {
+		case Addition:
+			return a + b
+		case Subtraction:
+			return a - b
+		case Multiplication:
+			return a * b
+		case Division:
+			return a / b
+		default:
+			return math.Mod(a, b)
+		}
+	}
+	x, y := decimal.NewFromFloat(a), decimal.NewFromFloat(b)
+	var f float64
+	switch operator {
+	case Addition:
+		f, _ = x.Add(y).Float64()
+	case Subtraction:
+		f, _ = x.Sub(y).Float64()
+	case Multiplication:
+		f, _ = x.Mul(y).Float64()
+	case Division:
+		f, _ = x.Div(y).Float64()
+	default:
+		f, _ = x.Mod(y).Float64()
+	}
+	return f
+}
+
 // NewElements 生成 Elements 接口的对象
 func NewElements(t Type, l int) []Element {
 	var ne []Element
@@ -504,6 +700,12 @@ func NewElements(t Type, l int) []Element {
 			x = new(floatElement)
 		case Bool:
 			x = new(boolElement)
+		case Datetime:
+			x = new(timeElement)
+		default:
+			if t.IsList() {
+				x = &listElement{t: t.Elem()}
+			}
 		}
 		ne = append(ne, x)
 	}
@@ -512,14 +714,13 @@ func NewElements(t Type, l int) []Element {
 
 // Arithmetic 算术运算
 func (s *Series) Arithmetic(operator ArithmeticOperator, x Series) (*Series, error) {
-	if s.t == Bool || x.t == Bool {
-		return nil, fmt.Errorf("布尔值不支持算术运算")
+	for _, t := range []Type{s.t, x.t} {
+		if t == Bool || t == Datetime || t.IsList() || (operator != Addition && t == String) {
+			return nil, &UnsupportedOperationError{Op: operator.String(), Type: t}
+		}
 	}
 	if s.Len() != x.Len() {
-		return nil, fmt.Errorf("长度不相等！")
-	}
-	if operator != 0 && (s.t == String || x.t == String) {
-		return nil, fmt.Errorf("字符串不支持该操作")
+		return nil, &LengthMismatchError{Want: s.Len(), Got: x.Len()}
 	}
 
 	ns := &Series{Name: s.Name}
@@ -529,7 +730,7 @@ func (s *Series) Arithmetic(operator ArithmeticOperator, x Series) (*Series, err
 	} else if s.t == Float || x.t == Float {
 		ns.t = Float
 	} else {
-		if operator == 3 {
+		if operator == Division {
 			ns.t = Float
 		} else {
 			ns.t = Int
@@ -537,49 +738,14 @@ func (s *Series) Arithmetic(operator ArithmeticOperator, x Series) (*Series, err
 	}
 	ns.elements = NewElements(ns.t, s.Len())
 
-	switch operator {
-	case 0:
-		for i := 0; i < s.Len(); i++ {
+	CurrentParallel().For(s.Len(), func(start, end int) {
+		for i := start; i < end; i++ {
 			if ns.t == String {
-				ns.Element(i).Set(s.Element(i).Records() + x.Element(i).Records())
+				ns.elements[i].Set(s.elements[i].Records() + x.elements[i].Records())
 			} else {
-				f, _ := decimal.NewFromFloat(s.Element(i).Float()).
-					Add(decimal.NewFromFloat(x.Element(i).Float())).Float64()
-				ns.Element(i).Set(f)
+				ns.elements[i].Set(arithmetic(operator, s.elements[i].Float(), x.elements[i].Float()))
 			}
 		}
-	case 1:
-		for i := 0; i < s.Len(); i++ {
-			f, _ := decimal.NewFromFloat(s.Element(i).Float()).
-				Sub(decimal.NewFromFloat(x.Element(i).Float())).Float64()
-			ns.Element(i).Set(f)
-		}
-	case 2:
-		for i := 0; i < s.Len(); i++ {
-			f, _ := decimal.NewFromFloat(s.Element(i).Float()).
-				Mul(decimal.NewFromFloat(x.Element(i).Float())).Float64()
-			ns.Element(i).Set(f)
-		}
-	case 3:
-		for i := 0; i < s.Len(); i++ {
-			if x.Element(i).Float() == 0 {
-				ns.Element(i).Set(math.NaN())
-			} else {
-				f, _ := decimal.NewFromFloat(s.Element(i).Float()).
-					Div(decimal.NewFromFloat(x.Element(i).Float())).Float64()
-				ns.Element(i).Set(f)
-			}
-		}
-	case 4:
-		for i := 0; i < s.Len(); i++ {
-			if x.Element(i).Float() == 0 {
-				ns.Element(i).Set(math.NaN())
-			} else {
-				f, _ := decimal.NewFromFloat(s.Element(i).Float()).
-					Mod(decimal.NewFromFloat(x.Element(i).Float())).Float64()
-				ns.Element(i).Set(f)
-			}
-		}
-	}
+	})
 	return ns, nil
 }
@@ -1,7 +1,10 @@
 package series
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
+	"time"
 )
 
 func ExampleNewSeries() {
@@ -28,7 +31,28 @@ func ExampleNewSeries_err() {
 	s1, err := NewSeries([]string{"1", "2", "test", "2", "4", "6"}, Float, "number3")
 	fmt.Println(s1, err)
 	//	output:
-	//<nil> 输入切片与指定数据类型不匹配
+	//<nil> 数据类型不匹配：期望 []float64，实际 []string
+}
+
+func ExampleSeries_Arithmetic_errors() {
+	s1, _ := NewSeries([]int{1, 2, 3}, Int, "a")
+	s2, _ := NewSeries([]int{1, 2}, Int, "b")
+	_, err := s1.Arithmetic(Addition, *s2)
+	var lengthErr *LengthMismatchError
+	if errors.As(err, &lengthErr) {
+		fmt.Println(lengthErr.Want, lengthErr.Got)
+	}
+
+	s3, _ := NewSeries([]bool{true, false, true}, Bool, "c")
+	_, err = s1.Arithmetic(Subtraction, *s3)
+	fmt.Println(errors.Is(err, ErrUnsupportedOperation), err)
+
+	_, err = DefaultNumberParser.ParseInt("1.5")
+	fmt.Println(errors.Is(err, ErrParse), err)
+	//	output:
+	//3 2
+	//true bool 类型数据不支持操作 Subtraction
+	//true "1.5" 不能转换为 int
 }
 
 func ExampleSeries_Append() {
@@ -65,10 +89,9 @@ func ExampleSeries_SetType() {
 	//	output:
 	//	字段名：number1
 	//数 据：[1 2 test 2 4 6]
-	//索引：[0 1 2 3 4 5]
+	//索 引：[0 1 2 3 4 5]
 	//类 型：string
 	//
-	//test 不能转换为 Int，已置为无限小
 	//字段名：number1
 	//数 据：[1 2 NaN 2 4 6]
 	//索 引：[0 1 2 3 4 5]
@@ -81,7 +104,6 @@ func ExampleSeries_Concat() {
 	_ = s1.Concat(*s2)
 	fmt.Println(s1)
 	// Output:
-	//两个数据类型不同，正在尝试转换... done
 	//字段名：number1
 	//数 据：[1 2 test 2 4 6 1 2 3 4 6]
 	//索 引：[0 1 2 3 4 5 6 7 8 9 10]
@@ -343,3 +365,409 @@ func ExampleSeries_Arithmetic_a2() {
 	//类 型：string
 
 }
+
+func ExampleList() {
+	s1, _ := NewSeries([][]string{{"a", "b"}, {}, {"c"}}, List(String), "tags")
+	_ = s1.Append([]string{"b", "d"})
+	fmt.Println(s1)
+	fmt.Println(s1.Element(0).(ListElement).Len(), s1.Element(3).(ListElement).Get(1))
+	ns, _ := s1.Filter(Contains, "b")
+	fmt.Println(ns.Records())
+	//	output:
+	//字段名：tags
+	//数 据：[["a","b"] [] ["c"] ["b","d"]]
+	//索 引：[0 1 2 3]
+	//类 型：[]string
+	//
+	//2 d
+	//[["a","b"] ["b","d"]]
+}
+
+func ExampleSeries_Explode() {
+	s1 := LoadRecords([]string{"[1,2]", "NaN", "[3]"}, List(Int), "sku")
+	ns, indexes, _ := s1.Explode()
+	fmt.Println(ns)
+	fmt.Println(indexes)
+	//	output:
+	//字段名：sku
+	//数 据：[1 2 NaN 3]
+	//索 引：[0 0 1 2]
+	//类 型：int
+	//
+	//[0 0 1 2]
+}
+
+func ExampleListElement_Contains() {
+	s1 := LoadRecords([]string{"[1,2]", "[1.5]"}, List(Float), "price")
+	s2 := LoadRecords([]string{"[1,2]"}, List(Int), "sku")
+	prices, skus := s1.Element(0).(ListElement), s2.Element(0).(ListElement)
+	fmt.Println(prices.Contains(1), prices.Contains(1.0), prices.Contains(int64(2)), prices.Contains("1"))
+	fmt.Println(skus.Contains(1), skus.Contains(int64(2)), skus.Contains(uint8(1)), skus.Contains(1.0), skus.Contains(1.5))
+	ns, _ := s1.Filter(Contains, 1.5)
+	fmt.Println(ns.Records())
+	//	output:
+	//true true true false
+	//true true true true false
+	//[[1.5]]
+}
+
+func ExampleSeries_Explode_bool() {
+	s1 := LoadRecords([]string{"[true]", "[]", "NaN"}, List(Bool), "pass")
+	ns, indexes, _ := s1.Explode()
+	fmt.Println(ns.Records(), indexes)
+	//	output:
+	//[true false false] [0 1 2]
+}
+
+func ExampleSeries_Explode_mismatch() {
+	s1 := LoadRecords([]string{"[1,2]"}, List(Int), "sku")
+	_ = s1.Append(3)
+	_, _, err := s1.Explode()
+	fmt.Println(errors.Is(err, ErrTypeMismatch), err)
+	fmt.Printf("%q\n", s1.Str().Join("-").Records())
+	//	output:
+	//true 数据类型不匹配：期望 []int，实际 int
+	//["1-2" "NaN"]
+}
+
+func ExampleSeries_Str() {
+	s1, _ := NewSeries([]string{" Apple ", "NaN", "banana", "Cherry pie"}, String, "fruit")
+	fmt.Println(s1.Str().Trim("").Str().Upper().Records())
+	fmt.Println(s1.Str().Len().Records())
+	fmt.Println(s1.Str().Slice(0, 3).Records())
+	fmt.Println(s1.Str().Contains("an").Records())
+	fmt.Println(s1.Str().Pad(8, '*', true).Records())
+	fmt.Println(s1.Str().Count("a").Records())
+	split := s1.Str().Split(" ", -1)
+	fmt.Println(split.Type(), split.Records())
+	fmt.Printf("%q\n", split.Str().Join("-").Records())
+	//	output:
+	//[APPLE NaN BANANA CHERRY PIE]
+	//[7 NaN 6 10]
+	//[ Ap NaN ban Che]
+	//[false false true false]
+	//[* Apple  NaN **banana Cherry pie]
+	//[0 NaN 3 0]
+	//[]string [["","Apple",""] NaN ["banana"] ["Cherry","pie"]]
+	//["-Apple-" "NaN" "banana" "Cherry-pie"]
+}
+
+func ExampleSeries_Filter_regex() {
+	s1, _ := NewSeries([]string{"订单 A1024 已发货", "NaN", "联系 13800138000", "订单 B7 取消"}, String, "memo")
+	ns, _ := s1.Filter(Regex, `订单 [A-Z]\d+`)
+	fmt.Println(ns.Records(), ns.Indexes())
+	orders, _ := s1.Str().Extract(`(?P<prefix>[A-Z])(\d+)`)
+	for _, order := range orders {
+		fmt.Println(order.Name, order.Records())
+	}
+	phones, _ := s1.Str().ReplaceRegex(`(\d{3})\d{4}(\d{4})`, "$1****$2")
+	fmt.Println(phones.Records())
+	//	output:
+	//[订单 A1024 已发货 订单 B7 取消] [0 3]
+	//prefix [A NaN NaN B]
+	//1 [1024 NaN NaN 7]
+	//[订单 A1024 已发货 NaN 联系 138****8000 订单 B7 取消]
+}
+
+func ExampleStringMethods_Join() {
+	s1, _ := LoadJSON([]any{[]any{"a", nil, "c"}, []any{"a", "b"}, nil}, List(String), "tags")
+	fmt.Printf("%q\n", s1.Str().Join("-").Records())
+	s2, _ := NewSeries([]string{"a,,c", "b"}, String, "tags")
+	fmt.Printf("%q\n", s2.Str().Split(",", -1).Str().Join("-").Records())
+	//	output:
+	//["NaN" "a-b" "NaN"]
+	//["a--c" "b"]
+}
+
+func ExampleSeries_Str_normalize() {
+	s1, _ := NewSeries([]string{" ＡＢＣ１２３，　", "電話號碼", "ｱｲｳ"}, String, "memo")
+	fmt.Println(s1.Str().Normalize(HalfWidth | TrimSpace | Simplified).Records())
+	fmt.Println(s1.Str().NFKC().Records())
+	fmt.Println(Normalize("臺灣", Simplified))
+	//	output:
+	//[ABC123, 电话号码 ｱｲｳ]
+	//[ ABC123,  電話號碼 アイウ]
+	//台湾
+}
+
+func ExampleNumberParser() {
+	p := NewNumberParser()
+	for _, s := range []string{"1,234", "12.5%", "¥300", "(1,000.5)", "3.2万", "-2.5亿元", "abc"} {
+		f, err := p.ParseFloat(s)
+		fmt.Println(f, err)
+	}
+	//	output:
+	//1234 <nil>
+	//0.125 <nil>
+	//300 <nil>
+	//-1000.5 <nil>
+	//32000 <nil>
+	//-2.5e+08 <nil>
+	//NaN "abc" 不能转换为 float64
+}
+
+func ExampleNumberParser_ParseInt() {
+	p := NewNumberParser()
+	for _, s := range []string{"9223372036854775807", "9223372036854775808", "9,223,372,036,854,775,808"} {
+		i, err := p.ParseInt(s)
+		fmt.Println(i, err)
+	}
+	//	output:
+	//9223372036854775807 <nil>
+	//-9223372036854775808 "9223372036854775808" 不能转换为 int
+	//-9223372036854775808 "9,223,372,036,854,775,808" 不能转换为 int
+}
+
+func ExampleLoadRecordsWith() {
+	s1, errs := LoadRecordsWith([]string{"1,234", "3.2万", "NaN", "12.5%", "N/A"}, Int, "amount", LoadOptions{})
+	fmt.Println(s1.Records())
+	for _, err := range errs {
+		fmt.Println(err)
+	}
+	//	output:
+	//[1234 32000 NaN NaN NaN]
+	//第 3 个元素 "12.5%" 不能转换为 int
+	//第 4 个元素 "N/A" 不能转换为 int
+}
+
+func ExampleInferType() {
+	fmt.Println(InferType([]string{"1", "2,000", "NaN", "3万"}, LoadOptions{}))
+	fmt.Println(InferType([]string{"1.5", "2", "12%"}, LoadOptions{}))
+	fmt.Println(InferType([]string{"true", "", "FALSE"}, LoadOptions{}))
+	fmt.Println(InferType([]string{"00123", "00456"}, LoadOptions{}))
+	fmt.Println(InferType([]string{"2024-01-02", "2024-01-03"}, LoadOptions{}))
+	fmt.Println(InferType([]string{"12", "-", "无"}, LoadOptions{NAValues: []string{"-", "无"}}))
+	//	output:
+	//int
+	//float64
+	//bool
+	//string
+	//datetime
+	//int
+}
+
+func ExampleInferType_bool() {
+	values := []string{"TRUE", "FALSE", "False", "true"}
+	t := InferType(values, LoadOptions{})
+	s, _ := LoadRecordsWith(values, t, "ok", LoadOptions{})
+	fmt.Println(t, s.Records())
+	//	output:
+	//bool [true false false true]
+}
+
+func ExampleSeries_UnmarshalBinary_version() {
+	s1, _ := NewSeries([]int{1, 2}, Int, "a")
+	data, _ := s1.MarshalBinary()
+	var s2 Series
+	for _, version := range []byte{3, 0, 2} {
+		data[0] = version
+		err := s2.UnmarshalBinary(data)
+		fmt.Println(errors.Is(err, ErrInvalidArgument), errors.Is(err, ErrCorruptData), err)
+	}
+	fmt.Println(s2.Records())
+	//	output:
+	//true false 参数错误: 不支持的格式版本 3
+	//false true 数据已损坏
+	//false false <nil>
+	//[1 2]
+}
+
+func ExampleInferType_datetime() {
+	values := []string{"2024-03-01", "2024-01-02 08:30:00", "", "2024-02-01T00:00:00+08:00"}
+	t := InferType(values, LoadOptions{})
+	s, _ := LoadRecordsWith(values, t, "date", LoadOptions{})
+	fmt.Println(t, s.Records())
+	sorted, _ := s.SubSet(s.SortIndex(false)...)
+	fmt.Println(sorted.Records())
+	f, _ := s.Filter(GreaterOrEqual, time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC))
+	fmt.Println(f.Records())
+
+	var c Series
+	data, _ := sorted.MarshalBinary()
+	_ = c.UnmarshalBinary(data)
+	fmt.Println(c.Type(), c.Records())
+	//	output:
+	//datetime [2024-03-01 2024-01-02 08:30:00 NaN 2024-02-01T00:00:00+08:00]
+	//[NaN 2024-01-02 08:30:00 2024-02-01T00:00:00+08:00 2024-03-01]
+	//[2024-03-01]
+	//datetime [NaN 2024-01-02 08:30:00 2024-02-01T00:00:00+08:00 2024-03-01]
+}
+
+func ExampleLoadRecordsWith_bool() {
+	values := []string{"true", "yes", "0", "否", "garbage"}
+	s1, errs := LoadRecordsWith(values, Bool, "pass", LoadOptions{})
+	fmt.Println(s1.Records(), len(errs))
+	s2, errs := LoadRecordsWith(values, Bool, "pass", LoadOptions{Strict: true})
+	fmt.Println(s2 == nil)
+	for _, err := range errs {
+		fmt.Println(err)
+	}
+	//	output:
+	//[true false false false false] 3
+	//true
+	//第 1 个元素 "yes" 不能转换为 bool
+	//第 3 个元素 "否" 不能转换为 bool
+	//第 4 个元素 "garbage" 不能转换为 bool
+}
+
+func ExampleLoadRecordsWith_na() {
+	opts := LoadOptions{NAValues: []string{"-", "N/A", "无", "#N/A", "--"}, KeepEmpty: true}
+	s1, errs := LoadRecordsWith([]string{"12", "-", "无", "#N/A", ""}, Int, "amount", opts)
+	fmt.Println(s1.Records(), len(errs))
+	s2, _ := LoadRecordsWith([]string{"a", "--", "", "N/A"}, String, "memo", opts)
+	fmt.Printf("%q\n", s2.Copy().Records())
+	//	output:
+	//[12 NaN NaN NaN NaN] 0
+	//["a" "NaN" "" "NaN"]
+}
+
+func ExampleLocalize() {
+	s1, _ := NewSeries([]int{1, 2}, Int, "a")
+	_, err := s1.Filter(Contains, 1)
+	fmt.Println(err)
+	fmt.Println(Localize(err, En))
+	fmt.Print(s1.StringIn(En))
+
+	SetLanguage(En)
+	defer SetLanguage(Zh)
+	_, err = s1.SubSet(5)
+	fmt.Println(err)
+	//	output:
+	//int 类型数据不支持操作 Contains
+	//int series does not support operation Contains
+	//Name: a
+	//Data: [1 2]
+	//Index: [0 1]
+	//Type: int
+	//index out of range: 5
+}
+
+func ExampleSeries_At() {
+	s1, _ := NewSeries([]bool{true, false, true}, Bool, "ok")
+	_, err := s1.At(3)
+	fmt.Println(err)
+
+	elem, _ := s1.At(0)
+	fmt.Println(elem.TrySet("yes"), elem.Bool())
+	fmt.Println(elem.TrySet("false"), elem.Bool())
+	fmt.Println(elem.TrySet([]int{1}))
+
+	s2, _ := NewSeries([]bool{true, false, true}, Bool, "ok")
+	fmt.Println(s2.SortIndex(false))
+	s3, _ := s2.Filter(Equal, []bool{true})
+	fmt.Println(s3)
+	//	output:
+	//索引越界: 3
+	//"yes" 不能转换为 bool true
+	//<nil> false
+	//数据类型不匹配：期望 bool，实际 []int
+	//[1 0 2]
+	//<nil>
+}
+
+func ExampleSeries_Copy_independent() {
+	s1, _ := NewSeries([]int{1, 2, 3}, Int, "a")
+	s2 := s1.Copy()
+	s2.Element(0).Set(10)
+	s3 := s1.Drop(0)
+	fmt.Println(s1.Records(), s1.Indexes())
+	fmt.Println(s2.Records(), s2.Indexes())
+	fmt.Println(s3.Records(), s3.Indexes())
+	//	output:
+	//[1 2 3] [0 1 2]
+	//[10 2 3] [0 1 2]
+	//[2 3] [1 2]
+}
+
+func ExampleSeries_Copy_element() {
+	s1, _ := NewSeries([]int{1, 2, 3}, Int, "a")
+	e := s1.Element(0)
+	s2 := s1.Copy()
+	s3, _ := s1.SubSet(0, 1)
+	e.Set(10)
+	fmt.Println(s1.Records(), s2.Records(), s3.Records())
+	e2, _ := s2.At(1)
+	s4 := s2.Copy()
+	e2.Set(20)
+	fmt.Println(s1.Records(), s2.Records(), s4.Records())
+	//	output:
+	//[10 2 3] [1 2 3] [1 2]
+	//[10 2 3] [1 20 3] [1 2 3]
+}
+
+func ExampleSeries_SetAt() {
+	s1, _ := NewSeries([]int{1, 2, 3}, Int, "a")
+	s2 := s1.Copy()
+	_ = s1.SetAt(0, 10)
+	err := s1.SetAt(3, 10)
+	fmt.Println(s1.Records(), s2.Records(), err)
+	//	output:
+	//[10 2 3] [1 2 3] 索引越界: 3
+}
+
+func ExampleSetParallel() {
+	SetParallel(Parallel{Workers: 4, ChunkSize: 2})
+	defer SetParallel(Parallel{})
+
+	s1, _ := NewSeries([]int{1, 2, 3, 4, 5, 6, 7}, Int, "a")
+	s2, _ := NewSeries([]float64{0.1, 0.2, 0.3, 0.4, 0.5, 0.6, 0.7}, Float, "b")
+	ns, _ := s1.Arithmetic(Addition, *s2)
+	fmt.Println(ns.Records())
+
+	fs, _ := ns.Filter(GreaterThan, 3.0)
+	fmt.Println(fs.Indexes())
+
+	_ = s1.SetType(String)
+	fmt.Println(s1.Records(), s1.Type())
+	//	output:
+	//[1.1 2.2 3.3 4.4 5.5 6.6 7.7]
+	//[2 3 4 5 6]
+	//[1 2 3 4 5 6 7] string
+}
+
+func ExampleSeries_MarshalJSON() {
+	s := LoadRecords([]string{"1", "NaN", "2.5"}, Float, "score")
+	b, _ := json.Marshal(s)
+	fmt.Println(string(b))
+
+	var ns Series
+	_ = json.Unmarshal([]byte(`{"name":"tags","data":[[1,2],null,[]]}`), &ns)
+	fmt.Println(ns.Name, ns.Type(), ns.Records())
+	// output:
+	//{"name":"score","type":"float64","data":[1.0,null,2.5]}
+	//tags []int [[1,2] NaN []]
+}
+
+func ExampleLoadJSON() {
+	s, _ := LoadJSON([]any{true, nil, false}, "", "pass")
+	b, _ := json.Marshal(s)
+	fmt.Println(s.Type(), s.Records())
+	fmt.Println(string(b))
+	// output:
+	//bool [true false false]
+	//{"name":"pass","type":"bool","data":[true,false,false]}
+}
+
+func ExampleLoadJSON_strings() {
+	s1, _ := LoadJSON([]any{"", "null", "NaN", nil}, "", "memo")
+	b, _ := json.Marshal(s1)
+	fmt.Println(string(b), s1.HasNaN())
+	s2, _ := LoadJSON([]any{[]any{"NaN", nil, ""}}, "", "tags")
+	b, _ = json.Marshal(s2)
+	fmt.Println(string(b))
+
+	// 二进制数据保留字符串 "NaN"，旧版本（版本 1）中的 "NaN" 读取为空值
+	var s3 Series
+	data, _ := s1.MarshalBinary()
+	_ = s3.UnmarshalBinary(data)
+	b, _ = json.Marshal(&s3)
+	fmt.Println(string(b))
+	_ = s3.UnmarshalBinary([]byte{1, 1, 'a', 6, 's', 't', 'r', 'i', 'n', 'g', 2, 1, 'x', 3, 'N', 'a', 'N', 0})
+	b, _ = json.Marshal(&s3)
+	fmt.Println(string(b))
+	// output:
+	//{"name":"memo","type":"string","data":["","null","NaN",null]} true
+	//{"name":"tags","type":"[]string","data":[["NaN",null,""]]}
+	//{"name":"memo","type":"string","data":["","null","NaN",null]}
+	//{"name":"a","type":"string","data":["x",null]}
+}
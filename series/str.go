@@ -0,0 +1,252 @@
+/**
+  Copyright (c) [2024] [JiangNan]
+  [pandas] is licensed under Mulan PSL v2.
+  You can use this software according to the terms and conditions of the Mulan PSL v2.
+  You may obtain a copy of Mulan PSL v2 at:
+           http://license.coscl.org.cn/MulanPSL2
+  THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND, EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT, MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+  See the Mulan PSL v2 for more details.
+*/
+
+package series
+
+import (
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// StringMethods 字符串向量化操作，通过 Series.Str 获取
+//
+//	非字符串类型数据集按 Records 的字符串处理，空值（NaN）结果仍为空值，
+//	返回布尔值的操作空值结果为 false，结果中的空字符串 "" 作为有效值保留
+type StringMethods struct {
+	s *Series
+}
+
+// Str 返回数据集的字符串操作对象
+func (s *Series) Str() *StringMethods {
+	return &StringMethods{s: s}
+}
+
+// 对每个非空元素的字符串执行 f，生成类型为 t 的新数据集
+func (sm *StringMethods) apply(t Type, f func(string) any) *Series {
+	return sm.applyElement(t, func(element Element) any { return f(element.Records()) })
+}
+
+// 对每个非空元素执行 f，生成类型为 t 的新数据集
+func (sm *StringMethods) applyElement(t Type, f func(Element) any) *Series {
+	ns := &Series{
+		Name:     sm.s.Name,
+		elements: NewElements(t, sm.s.Len()),
+		t:        t,
+		indexes:  slices.Clone(sm.s.indexes),
+	}
+	for i, element := range sm.s.elements {
+		if element.isNaN() {
+			if t != Bool {
+				ns.elements[i].Set("NaN")
+			}
+			continue
+		}
+		setResult(ns.elements[i], f(element))
+	}
+	return ns
+}
+
+// 写入 f 的结果，字符串按原值保存，空字符串不视为空值；结果为 nil 时置为空值
+func setResult(element Element, value any) {
+	switch v := value.(type) {
+	case string:
+		*element.(*stringElement) = stringElement(v)
+	case []string:
+		l := element.(*listElement)
+		l.values = make([]Element, 0, len(v))
+		for _, item := range v {
+			x := stringElement(item)
+			l.values = append(l.values, &x)
+		}
+	default:
+		element.Set(value)
+	}
+}
+
+// Lower 转为小写
+func (sm *StringMethods) Lower() *Series {
+	return sm.apply(String, func(s string) any { return strings.ToLower(s) })
+}
+
+// Upper 转为大写
+func (sm *StringMethods) Upper() *Series {
+	return sm.apply(String, func(s string) any { return strings.ToUpper(s) })
+}
+
+// Trim 去除首尾字符
+//
+//	cutset: 需要去除的字符集合，为空时去除首尾空白
+func (sm *StringMethods) Trim(cutset string) *Series {
+	return sm.apply(String, func(s string) any {
+		if cutset == "" {
+			return strings.TrimSpace(s)
+		}
+		return strings.Trim(s, cutset)
+	})
+}
+
+// Split 按 sep 分割，返回 List(String) 类型数据集
+//
+//	n: 同 strings.SplitN，n < 0 时全部分割
+func (sm *StringMethods) Split(sep string, n int) *Series {
+	return sm.apply(List(String), func(s string) any { return strings.SplitN(s, sep, n) })
+}
+
+// Replace 替换子串
+//
+//	n: 同 strings.Replace，n < 0 时全部替换
+func (sm *StringMethods) Replace(old, new string, n int) *Series {
+	return sm.apply(String, func(s string) any { return strings.Replace(s, old, new, n) })
+}
+
+// Slice 按字符截取 [start, stop)，负数表示从末尾计数，超出范围时截断
+func (sm *StringMethods) Slice(start, stop int) *Series {
+	return sm.apply(String, func(s string) any {
+		r := []rune(s)
+		i, j := sliceBound(start, len(r)), sliceBound(stop, len(r))
+		if i >= j {
+			return ""
+		}
+		return string(r[i:j])
+	})
+}
+
+// Len 返回字符数，Int 类型数据集
+func (sm *StringMethods) Len() *Series {
+	return sm.apply(Int, func(s string) any { return utf8.RuneCountInString(s) })
+}
+
+// Pad 填充至指定字符数，长度已足够时不变
+//
+//	width: 目标字符数
+//	fill: 填充字符
+//	left: 是否在左侧填充，否则在右侧填充
+func (sm *StringMethods) Pad(width int, fill rune, left bool) *Series {
+	return sm.apply(String, func(s string) any {
+		n := width - utf8.RuneCountInString(s)
+		if n <= 0 {
+			return s
+		}
+		if left {
+			return strings.Repeat(string(fill), n) + s
+		}
+		return s + strings.Repeat(string(fill), n)
+	})
+}
+
+// Contains 是否包含子串，Bool 类型数据集
+func (sm *StringMethods) Contains(substr string) *Series {
+	return sm.apply(Bool, func(s string) any { return strings.Contains(s, substr) })
+}
+
+// StartsWith 是否以 prefix 开始，Bool 类型数据集
+func (sm *StringMethods) StartsWith(prefix string) *Series {
+	return sm.apply(Bool, func(s string) any { return strings.HasPrefix(s, prefix) })
+}
+
+// EndsWith 是否以 suffix 结束，Bool 类型数据集
+func (sm *StringMethods) EndsWith(suffix string) *Series {
+	return sm.apply(Bool, func(s string) any { return strings.HasSuffix(s, suffix) })
+}
+
+// Count 子串出现次数，Int 类型数据集
+func (sm *StringMethods) Count(substr string) *Series {
+	return sm.apply(Int, func(s string) any { return strings.Count(s, substr) })
+}
+
+// Join 用 sep 连接，列表类型数据集连接列表中的元素，其他类型连接每个字符。
+// 同 pandas，列表中含有空值时结果为空值
+func (sm *StringMethods) Join(sep string) *Series {
+	if !sm.s.t.IsList() {
+		return sm.apply(String, func(s string) any { return strings.Join(strings.Split(s, ""), sep) })
+	}
+	return sm.applyElement(String, func(element Element) any {
+		l, ok := element.(*listElement)
+		if !ok {
+			return nil
+		}
+		var items []string
+		for _, value := range l.values {
+			if value.isNaN() {
+				return nil
+			}
+			items = append(items, value.Records())
+		}
+		return strings.Join(items, sep)
+	})
+}
+
+// ReplaceRegex 替换匹配正则表达式的内容，repl 支持 $1、${name} 引用分组
+func (sm *StringMethods) ReplaceRegex(pattern, repl string) (*Series, error) {
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+	return sm.apply(String, func(s string) any { return re.ReplaceAllString(s, repl) }), nil
+}
+
+// Extract 提取正则表达式第一个匹配的各分组，每个分组生成一个 String 类型数据集
+//
+//	命名分组以分组名作为数据集名称，其余以分组序号（从 0 开始）命名，
+//	未匹配的元素及未参与匹配的分组为空值，匹配到空字符串的分组为 ""
+func (sm *StringMethods) Extract(pattern string) ([]*Series, error) {
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+	if re.NumSubexp() == 0 {
+		return nil, NewError(ErrInvalidArgument, MsgRegexNoGroup, pattern)
+	}
+	matches := make([][]int, sm.s.Len())
+	records := make([]string, sm.s.Len())
+	for i, element := range sm.s.elements {
+		if !element.isNaN() {
+			records[i] = element.Records()
+			matches[i] = re.FindStringSubmatchIndex(records[i])
+		}
+	}
+	var columns []*Series
+	for i, name := range re.SubexpNames()[1:] {
+		if name == "" {
+			name = strconv.Itoa(i)
+		}
+		ns := &Series{
+			Name:     name,
+			elements: NewElements(String, sm.s.Len()),
+			t:        String,
+			indexes:  slices.Clone(sm.s.indexes),
+		}
+		for j, match := range matches {
+			if start, end := 2*(i+1), 2*(i+1)+1; match == nil || match[start] < 0 {
+				ns.elements[j].Set("NaN")
+			} else {
+				setResult(ns.elements[j], records[j][match[start]:match[end]])
+			}
+		}
+		columns = append(columns, ns)
+	}
+	return columns, nil
+}
+
+// Repeat 重复 n 次
+func (sm *StringMethods) Repeat(n int) *Series {
+	return sm.apply(String, func(s string) any { return strings.Repeat(s, max(n, 0)) })
+}
+
+// 将可能为负数的下标转换为 [0, l] 范围内
+func sliceBound(i, l int) int {
+	if i < 0 {
+		i += l
+	}
+	return min(max(i, 0), l)
+}
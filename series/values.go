@@ -0,0 +1,89 @@
+/**
+  Copyright (c) [2024] [JiangNan]
+  [pandas] is licensed under Mulan PSL v2.
+  You can use this software according to the terms and conditions of the Mulan PSL v2.
+  You may obtain a copy of Mulan PSL v2 at:
+           http://license.coscl.org.cn/MulanPSL2
+  THIS SOFTWARE IS PROVIDED ON AN "AS IS" BASIS, WITHOUT WARRANTIES OF ANY KIND, EITHER EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO NON-INFRINGEMENT, MERCHANTABILITY OR FIT FOR A PARTICULAR PURPOSE.
+  See the Mulan PSL v2 for more details.
+*/
+
+package series
+
+import (
+	"encoding/json"
+)
+
+// LoadValues 用 Go 值创建数据列，值为 nil 时置为空值，Bool 类型没有空值，置为 false；
+// 字符串按原值保存，""、"null"、"NaN" 等不视为空值
+//
+//	values: 整数、浮点数、字符串、布尔值或由它们组成的 []any，String 类型中的 []any 及 map[string]any 记为 JSON 文本
+//	t: 数据类型，为空时根据数据推断，规则同 InferJSONType
+//	name: 数据列名称
+func LoadValues(values []any, t Type, name string) (*Series, error) {
+	if t == "" {
+		t = InferJSONType(values)
+	}
+	if !t.IsList() && t != String && t != Int && t != Float && t != Bool && t != Datetime {
+		return nil, NewError(ErrUnknownType, "%s", t)
+	}
+	ns := &Series{Name: name, t: t, elements: NewElements(t, len(values))}
+	for i, value := range values {
+		if value == nil {
+			// 布尔值没有空值，保持 false
+			if t != Bool {
+				ns.elements[i].Set("NaN")
+			}
+			continue
+		}
+		if t == String {
+			switch value.(type) {
+			case []any, map[string]any:
+				b, err := json.Marshal(value)
+				if err != nil {
+					return nil, err
+				}
+				value = string(b)
+			}
+		}
+		if err := setValue(ns.elements[i], value); err != nil {
+			if e, ok := err.(*ParseError); ok {
+				e.Index = i
+			}
+			return nil, err
+		}
+	}
+	ns.InitIndex()
+	return ns, nil
+}
+
+// 设置元素的值，字符串及列表中的字符串按原值保存，列表中的 nil 为空值
+func setValue(e Element, value any) error {
+	switch x := e.(type) {
+	case *stringElement:
+		if v, ok := value.(string); ok {
+			*x = stringElement(v)
+			return nil
+		}
+	case *listElement:
+		items, ok := value.([]any)
+		if !ok {
+			break
+		}
+		x.values = make([]Element, 0, len(items))
+		for _, item := range items {
+			v := NewElements(x.t, 1)[0]
+			if item == nil {
+				// 布尔值没有空值，保持 false
+				if x.t != Bool {
+					v.Set("NaN")
+				}
+			} else if err := setValue(v, item); err != nil {
+				return err
+			}
+			x.values = append(x.values, v)
+		}
+		return nil
+	}
+	return e.TrySet(value)
+}